@@ -0,0 +1,90 @@
+package cronrange
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	errInvalidBoundary = errors.New("boundary should be one of closed, closed-open, open-closed and open")
+)
+
+// Boundary controls whether the starting time and ending time are included in the time ranges.
+type Boundary uint8
+
+const (
+	// ClosedBoundary includes both the starting time and ending time, i.e. [start, end], it's the default.
+	ClosedBoundary Boundary = iota
+	// ClosedOpenBoundary includes the starting time but not the ending time, i.e. [start, end),
+	// so the back-to-back time ranges don't share the moment in between.
+	ClosedOpenBoundary
+	// OpenClosedBoundary includes the ending time but not the starting time, i.e. (start, end].
+	OpenClosedBoundary
+	// OpenBoundary includes neither the starting time nor the ending time, i.e. (start, end).
+	OpenBoundary
+)
+
+var boundaryNames = [...]string{
+	ClosedBoundary:     "closed",
+	ClosedOpenBoundary: "closed-open",
+	OpenClosedBoundary: "open-closed",
+	OpenBoundary:       "open",
+}
+
+// String returns the name of the boundary used in BD= part, e.g. "closed-open".
+func (b Boundary) String() string {
+	if int(b) < len(boundaryNames) {
+		return boundaryNames[b]
+	}
+	return fmt.Sprintf("Boundary(%d)", b)
+}
+
+func (b Boundary) isValid() bool {
+	return int(b) < len(boundaryNames)
+}
+
+func (b Boundary) openStart() bool {
+	return b == OpenClosedBoundary || b == OpenBoundary
+}
+
+func (b Boundary) openEnd() bool {
+	return b == ClosedOpenBoundary || b == OpenBoundary
+}
+
+// parseBoundary parses the value of BD= part like "closed-open".
+func parseBoundary(s string) (Boundary, error) {
+	for b, name := range boundaryNames {
+		if s == name {
+			return Boundary(b), nil
+		}
+	}
+	return ClosedBoundary, fmt.Errorf("%w: %q", errInvalidBoundary, s)
+}
+
+// Contains checks if the given time falls within the time range with the boundary.
+func (tr TimeRange) Contains(t time.Time, b Boundary) bool {
+	afterStart := t.After(tr.Start) || (!b.openStart() && t.Equal(tr.Start))
+	beforeEnd := t.Before(tr.End) || (!b.openEnd() && t.Equal(tr.End))
+	return afterStart && beforeEnd
+}
+
+// Overlaps checks if the time range shares any moment with the other one, both with the boundary,
+// e.g. the back-to-back time ranges only overlap with ClosedBoundary.
+func (tr TimeRange) Overlaps(other TimeRange, b Boundary) bool {
+	lo, hi := tr.Start, tr.End
+	if other.Start.After(lo) {
+		lo = other.Start
+	}
+	if other.End.Before(hi) {
+		hi = other.End
+	}
+	switch {
+	case lo.After(hi):
+		return false
+	case lo.Equal(hi):
+		return tr.Contains(lo, b) && other.Contains(lo, b)
+	default:
+		return true
+	}
+}
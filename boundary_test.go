@@ -0,0 +1,111 @@
+package cronrange
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseBoundary(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    Boundary
+		wantErr bool
+	}{
+		{"closed", ClosedBoundary, false},
+		{"closed-open", ClosedOpenBoundary, false},
+		{"open-closed", OpenClosedBoundary, false},
+		{"open", OpenBoundary, false},
+		{"", ClosedBoundary, true},
+		{"Open", ClosedBoundary, true},
+		{"half-open", ClosedBoundary, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			got, err := parseBoundary(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseBoundary() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("parseBoundary() got = %v, want %v", got, tt.want)
+			}
+			if !tt.wantErr && got.String() != tt.s {
+				t.Errorf("String() got = %v, want %v", got.String(), tt.s)
+			}
+		})
+	}
+	if s := Boundary(9).String(); s != "Boundary(9)" {
+		t.Errorf("String() got = %v, want Boundary(9)", s)
+	}
+}
+
+func TestTimeRange_Contains(t *testing.T) {
+	tr := TimeRange{parseTime(locationUTC, "2020-01-01 09:00:00"), parseTime(locationUTC, "2020-01-01 10:00:00")}
+	var (
+		before = parseTime(locationUTC, "2020-01-01 08:59:59")
+		start  = parseTime(locationUTC, "2020-01-01 09:00:00")
+		middle = parseTime(locationUTC, "2020-01-01 09:30:00")
+		end    = parseTime(locationUTC, "2020-01-01 10:00:00")
+		after  = parseTime(locationUTC, "2020-01-01 10:00:01")
+	)
+	tests := []struct {
+		name     string
+		boundary Boundary
+		t        time.Time
+		want     bool
+	}{
+		{"Closed before", ClosedBoundary, before, false},
+		{"Closed start", ClosedBoundary, start, true},
+		{"Closed middle", ClosedBoundary, middle, true},
+		{"Closed end", ClosedBoundary, end, true},
+		{"Closed after", ClosedBoundary, after, false},
+		{"Closed-open start", ClosedOpenBoundary, start, true},
+		{"Closed-open end", ClosedOpenBoundary, end, false},
+		{"Open-closed start", OpenClosedBoundary, start, false},
+		{"Open-closed end", OpenClosedBoundary, end, true},
+		{"Open start", OpenBoundary, start, false},
+		{"Open middle", OpenBoundary, middle, true},
+		{"Open end", OpenBoundary, end, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tr.Contains(tt.t, tt.boundary); got != tt.want {
+				t.Errorf("Contains() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimeRange_Overlaps(t *testing.T) {
+	var (
+		nine   = parseTime(locationUTC, "2020-01-01 09:00:00")
+		ten    = parseTime(locationUTC, "2020-01-01 10:00:00")
+		eleven = parseTime(locationUTC, "2020-01-01 11:00:00")
+		noon   = parseTime(locationUTC, "2020-01-01 12:00:00")
+	)
+	tests := []struct {
+		name     string
+		a, b     TimeRange
+		boundary Boundary
+		want     bool
+	}{
+		{"Disjoint", TimeRange{nine, ten}, TimeRange{eleven, noon}, ClosedBoundary, false},
+		{"Overlapping closed", TimeRange{nine, eleven}, TimeRange{ten, noon}, ClosedBoundary, true},
+		{"Overlapping open", TimeRange{nine, eleven}, TimeRange{ten, noon}, OpenBoundary, true},
+		{"Nested", TimeRange{nine, noon}, TimeRange{ten, eleven}, OpenBoundary, true},
+		{"Back-to-back closed", TimeRange{nine, ten}, TimeRange{ten, eleven}, ClosedBoundary, true},
+		{"Back-to-back closed reversed", TimeRange{ten, eleven}, TimeRange{nine, ten}, ClosedBoundary, true},
+		{"Back-to-back closed-open", TimeRange{nine, ten}, TimeRange{ten, eleven}, ClosedOpenBoundary, false},
+		{"Back-to-back open-closed", TimeRange{nine, ten}, TimeRange{ten, eleven}, OpenClosedBoundary, false},
+		{"Back-to-back open", TimeRange{nine, ten}, TimeRange{ten, eleven}, OpenBoundary, false},
+		{"Moment in closed", TimeRange{ten, ten}, TimeRange{nine, eleven}, ClosedBoundary, true},
+		{"Moment in closed-open", TimeRange{ten, ten}, TimeRange{nine, eleven}, ClosedOpenBoundary, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a.Overlaps(tt.b, tt.boundary); got != tt.want {
+				t.Errorf("Overlaps() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
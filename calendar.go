@@ -0,0 +1,247 @@
+package cronrange
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+var (
+	strCalendarComment = `#`
+	strDateLayout      = `2006-01-02`
+
+	errNilCalendar = errors.New("calendar should not be nil")
+)
+
+// maxShiftDays is the upper bound of consecutive non-business days to look through for shifting the holidays.
+const maxShiftDays = 31
+
+// Calendar tells the holidays, on which the time ranges of a CronRange are skipped or shifted.
+type Calendar interface {
+	// IsHoliday checks if the date of the given time is a holiday, the time is always the midnight of the date in the time zone of the CronRange.
+	IsHoliday(date time.Time) bool
+}
+
+// HolidayPolicy controls how the time ranges starting on holidays are treated.
+type HolidayPolicy uint8
+
+const (
+	// SkipHoliday drops the time ranges starting on holidays.
+	SkipHoliday HolidayPolicy = iota
+	// ShiftHoliday moves the time ranges starting on holidays to the same time of the next business day, i.e. a weekday which is not a holiday.
+	ShiftHoliday
+)
+
+// DateCalendar is an in-memory Calendar with a list of dates as holidays.
+type DateCalendar struct {
+	dates map[civilDate]struct{}
+}
+
+// civilDate is a date without time zone.
+type civilDate struct {
+	year  int
+	month time.Month
+	day   int
+}
+
+func civilDateOf(t time.Time) civilDate {
+	year, month, day := t.Date()
+	return civilDate{year, month, day}
+}
+
+// NewDateCalendar returns a DateCalendar with the given dates as holidays, only the dates in their own locations are taken.
+func NewDateCalendar(dates ...time.Time) *DateCalendar {
+	c := &DateCalendar{dates: make(map[civilDate]struct{}, len(dates))}
+	for _, d := range dates {
+		c.dates[civilDateOf(d)] = struct{}{}
+	}
+	return c
+}
+
+// ReadDateCalendar reads dates like "2025-12-25" line by line and returns a DateCalendar with them as holidays,
+// blank lines and the comments starting with '#' are ignored.
+func ReadDateCalendar(r io.Reader) (c *DateCalendar, err error) {
+	c = NewDateCalendar()
+	scanner := bufio.NewScanner(r)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := scanner.Text()
+		if idx := strings.Index(line, strCalendarComment); idx >= 0 {
+			line = line[:idx]
+		}
+		if line = strings.TrimSpace(line); line == "" {
+			continue
+		}
+
+		var date time.Time
+		if date, err = time.Parse(strDateLayout, line); err != nil {
+			return nil, fmt.Errorf("invalid date on line %d: %w", lineNum, err)
+		}
+		c.dates[civilDateOf(date)] = struct{}{}
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// LoadDateCalendar loads a DateCalendar from the file of dates, in the format accepted by ReadDateCalendar().
+func LoadDateCalendar(path string) (c *DateCalendar, err error) {
+	var f *os.File
+	if f, err = os.Open(path); err != nil {
+		return
+	}
+	defer f.Close()
+	return ReadDateCalendar(f)
+}
+
+// IsHoliday checks if the date of the given time in its location is in the list.
+func (c *DateCalendar) IsHoliday(date time.Time) bool {
+	_, found := c.dates[civilDateOf(date)]
+	return found
+}
+
+// Len returns the number of holidays in the list.
+func (c *DateCalendar) Len() int {
+	return len(c.dates)
+}
+
+// calendarSchedule skips or shifts the activations of the inner schedule on holidays, in the location or the location of given time if it's nil.
+type calendarSchedule struct {
+	inner  cron.Schedule
+	cal    Calendar
+	policy HolidayPolicy
+	loc    *time.Location
+}
+
+// startOfDay returns the midnight of the given time in its location.
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+// addDays returns the midnight of the date a number of days after the given midnight.
+func addDays(day time.Time, n int) time.Time {
+	year, month, d := day.Date()
+	return time.Date(year, month, d+n, 0, 0, 0, 0, day.Location())
+}
+
+func (s calendarSchedule) isBusinessDay(day time.Time) bool {
+	wd := day.Weekday()
+	return wd != time.Saturday && wd != time.Sunday && !s.cal.IsHoliday(day)
+}
+
+// Next returns the next activation time, later than the given time.
+// If no time can be found within five years, it returns the zero time like cron.SpecSchedule.
+func (s calendarSchedule) Next(t time.Time) time.Time {
+	loc := s.loc
+	if loc == nil {
+		loc = t.Location()
+	}
+	if s.policy == ShiftHoliday {
+		return s.nextShifted(t, loc)
+	}
+
+	limit := t.Add(maxSetSearch)
+	for curr := t; ; {
+		next := s.inner.Next(curr)
+		if next.IsZero() || next.After(limit) {
+			return time.Time{}
+		}
+		day := startOfDay(next.In(loc))
+		if !s.cal.IsHoliday(day) {
+			return next.In(t.Location())
+		}
+
+		// skip to the end of the holiday
+		curr = addDays(day, 1).Add(-time.Nanosecond)
+	}
+}
+
+// nextShifted returns the next activation time with the ones on holidays shifted to the next business day, later than the given time.
+// It checks day by day, and jumps over the days without any activations.
+func (s calendarSchedule) nextShifted(t time.Time, loc *time.Location) time.Time {
+	var (
+		limit = t.Add(maxSetSearch)
+		day   = startOfDay(t.In(loc))
+		// the holidays before the given time may be shifted later than it
+		checkShifted = true
+	)
+	for !day.After(limit) {
+		if next := s.nextOnDay(day, t); !next.IsZero() {
+			return next.In(t.Location())
+		}
+
+		// jump to the day of the next activation, or the next business day if activations on holidays may be shifted to it
+		var nextDay time.Time
+		if next := s.inner.Next(addDays(day, 1).Add(-time.Nanosecond)); !next.IsZero() {
+			nextDay = startOfDay(next.In(loc))
+		}
+		if checkShifted || s.cal.IsHoliday(day) {
+			for i, d := 1, addDays(day, 1); i <= maxShiftDays; i, d = i+1, addDays(d, 1) {
+				if s.isBusinessDay(d) {
+					if nextDay.IsZero() || d.Before(nextDay) {
+						nextDay = d
+					}
+					break
+				}
+			}
+		}
+		if nextDay.IsZero() {
+			break
+		}
+		day, checkShifted = nextDay, false
+	}
+	return time.Time{}
+}
+
+// nextOnDay returns the earliest activation on the day later than the given time, including the ones shifted from the preceding holidays,
+// or the zero time if there's none.
+func (s calendarSchedule) nextOnDay(day, t time.Time) (best time.Time) {
+	tomorrow := addDays(day, 1)
+	if !s.cal.IsHoliday(day) {
+		from := day.Add(-time.Nanosecond)
+		if t.After(from) {
+			from = t
+		}
+		if next := s.inner.Next(from); !next.IsZero() && next.Before(tomorrow) {
+			best = next
+		}
+	}
+	if !s.isBusinessDay(day) {
+		return
+	}
+
+	// look back through the non-business days for the holidays to shift
+	for i, d := 1, addDays(day, -1); i <= maxShiftDays && !s.isBusinessDay(d); i, d = i+1, addDays(d, -1) {
+		if !s.cal.IsHoliday(d) {
+			continue
+		}
+		from, end := d.Add(-time.Nanosecond), addDays(d, 1)
+		if !t.Before(day) {
+			// skip the activations shifted to the time before t, with an hour of margin for DST changes
+			local := t.In(day.Location())
+			hour, min, sec := local.Clock()
+			if clock := time.Date(d.Year(), d.Month(), d.Day(), hour-1, min, sec, local.Nanosecond(), d.Location()); clock.After(from) {
+				from = clock
+			}
+		}
+		for next := s.inner.Next(from); !next.IsZero() && next.Before(end); next = s.inner.Next(next) {
+			local := next.In(day.Location())
+			hour, min, sec := local.Clock()
+			shifted := time.Date(day.Year(), day.Month(), day.Day(), hour, min, sec, local.Nanosecond(), day.Location())
+			if shifted.After(t) {
+				if best.IsZero() || shifted.Before(best) {
+					best = shifted.In(t.Location())
+				}
+				break
+			}
+		}
+	}
+	return
+}
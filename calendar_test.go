@@ -0,0 +1,127 @@
+package cronrange
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReadDateCalendar(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantLen int
+		wantErr bool
+	}{
+		{"Empty", "", 0, false},
+		{"Blank lines and comments", "\n  \n# nothing\n", 0, false},
+		{"Dates", "2020-01-01\n2020-12-25\n", 2, false},
+		{"Dates with comments and spaces", "  2020-01-01 # New Year's Day\r\n\n2020-12-25\n2020-12-25", 2, false},
+		{"Invalid date", "2020-01-01\n2020-13-01\n", 0, true},
+		{"Invalid format", "01/01/2020", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadDateCalendar(strings.NewReader(tt.content))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ReadDateCalendar() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.Len() != tt.wantLen {
+				t.Errorf("ReadDateCalendar() got %d dates, want %d", got.Len(), tt.wantLen)
+			}
+		})
+	}
+}
+
+func TestLoadDateCalendar(t *testing.T) {
+	if _, err := LoadDateCalendar("testdata/missing.txt"); err == nil {
+		t.Errorf("LoadDateCalendar() of missing file got no error")
+	}
+
+	cal, err := LoadDateCalendar("testdata/holidays.txt")
+	if err != nil {
+		t.Errorf("LoadDateCalendar() error = %v", err)
+		return
+	}
+	if cal.Len() != 10 {
+		t.Errorf("LoadDateCalendar() got %d dates, want %d", cal.Len(), 10)
+	}
+	for _, tt := range []struct {
+		date time.Time
+		want bool
+	}{
+		{time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), true},
+		{time.Date(2020, 1, 1, 23, 59, 59, 0, locationTokyo), true},
+		{time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC), false},
+		{time.Date(2020, 10, 3, 0, 0, 0, 0, locationHonolulu), true},
+		{time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), false},
+	} {
+		if got := cal.IsHoliday(tt.date); got != tt.want {
+			t.Errorf("IsHoliday(%v) = %v, want %v", tt.date, got, tt.want)
+		}
+	}
+}
+
+var calendarBerlin = NewDateCalendar(
+	time.Date(2020, 4, 10, 0, 0, 0, 0, time.UTC),
+	time.Date(2020, 4, 13, 0, 0, 0, 0, time.UTC),
+	time.Date(2020, 12, 24, 0, 0, 0, 0, time.UTC),
+	time.Date(2020, 12, 25, 0, 0, 0, 0, time.UTC),
+)
+
+func TestCalendarSchedule_Next(t *testing.T) {
+	tests := []struct {
+		name   string
+		expr   string
+		policy HolidayPolicy
+		t      time.Time
+		want   time.Time
+	}{
+		{"Skip on business day", "0 2 * * *", SkipHoliday, parseTime(locationUTC, "2020-04-08 12:00:00"), parseTime(locationUTC, "2020-04-09 02:00:00")},
+		{"Skip holiday", "0 2 * * *", SkipHoliday, parseTime(locationUTC, "2020-04-09 12:00:00"), parseTime(locationUTC, "2020-04-11 02:00:00")},
+		{"Skip holidays in a row", "0 2 * * *", SkipHoliday, parseTime(locationUTC, "2020-12-23 12:00:00"), parseTime(locationUTC, "2020-12-26 02:00:00")},
+		{"Skip all day long", "*/30 * * * *", SkipHoliday, parseTime(locationUTC, "2020-04-09 23:45:00"), parseTime(locationUTC, "2020-04-11 00:00:00")},
+		{"Skip with weekly", "0 2 * * 5", SkipHoliday, parseTime(locationUTC, "2020-04-04 00:00:00"), parseTime(locationUTC, "2020-04-17 02:00:00")},
+		{"Shift on business day", "0 2 * * *", ShiftHoliday, parseTime(locationUTC, "2020-04-08 12:00:00"), parseTime(locationUTC, "2020-04-09 02:00:00")},
+		{"Shift keeps weekends", "0 2 * * *", ShiftHoliday, parseTime(locationUTC, "2020-04-09 12:00:00"), parseTime(locationUTC, "2020-04-11 02:00:00")},
+		{"Shift over weekend and holiday", "0 2 * * 5", ShiftHoliday, parseTime(locationUTC, "2020-04-04 00:00:00"), parseTime(locationUTC, "2020-04-14 02:00:00")},
+		{"Shift after the given time", "0 2 * * 5", ShiftHoliday, parseTime(locationUTC, "2020-04-13 12:00:00"), parseTime(locationUTC, "2020-04-14 02:00:00")},
+		{"Shift later on the same day", "0 2 * * 5", ShiftHoliday, parseTime(locationUTC, "2020-04-14 01:00:00"), parseTime(locationUTC, "2020-04-14 02:00:00")},
+		{"Shift passed", "0 2 * * 5", ShiftHoliday, parseTime(locationUTC, "2020-04-14 02:00:00"), parseTime(locationUTC, "2020-04-17 02:00:00")},
+		{"Shift merges with regular", "0 2 * * *", ShiftHoliday, parseTime(locationUTC, "2020-12-23 12:00:00"), parseTime(locationUTC, "2020-12-26 02:00:00")},
+		{"Shift holidays in a row", "0 2 * * 4,5", ShiftHoliday, parseTime(locationUTC, "2020-12-23 12:00:00"), parseTime(locationUTC, "2020-12-28 02:00:00")},
+		{"Shift earlier time first", "0 3 24 12 * | 0 1 25 12 *", ShiftHoliday, parseTime(locationUTC, "2020-12-23 12:00:00"), parseTime(locationUTC, "2020-12-28 01:00:00")},
+		{"Shift in other time zone", "0 2 * * 5", ShiftHoliday, parseTime(locationTokyo, "2020-04-04 00:00:00"), parseTime(locationTokyo, "2020-04-14 11:00:00")},
+		{"Skip in other time zone", "0 2 * * *", SkipHoliday, parseTime(locationTokyo, "2020-04-09 12:00:00"), parseTime(locationTokyo, "2020-04-11 11:00:00")},
+		{"Shift on business day in other time zone", "0 2 * * *", ShiftHoliday, parseTime(locationTokyo, "2020-04-08 12:00:00"), parseTime(locationTokyo, "2020-04-09 11:00:00")},
+		{"Never activates", "0 2 * * * 2019", ShiftHoliday, parseTime(locationUTC, "2020-04-04 00:00:00"), zeroTime},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inner, err := parseSchedule(tt.expr, timeZoneUTC, false)
+			if err != nil {
+				t.Errorf("parseSchedule() error = %v", err)
+				return
+			}
+			s := calendarSchedule{inner: inner, cal: calendarBerlin, policy: tt.policy, loc: locationUTC}
+			got := s.Next(tt.t)
+			if !got.Equal(tt.want) {
+				t.Errorf("Next() = %v, want %v", got, tt.want)
+			}
+			if !got.IsZero() && got.Location() != tt.t.Location() {
+				t.Errorf("Next() location = %v, want %v", got.Location(), tt.t.Location())
+			}
+		})
+	}
+}
+
+func BenchmarkCalendarSchedule_Next(b *testing.B) {
+	inner, _ := parseSchedule("0 2 * * 5", timeZoneUTC, false)
+	s := calendarSchedule{inner: inner, cal: calendarBerlin, policy: ShiftHoliday, loc: locationUTC}
+	t := parseTime(locationUTC, "2020-04-04 00:00:00")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = s.Next(t)
+	}
+}
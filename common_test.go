@@ -71,6 +71,22 @@ type tempTestWithInstance struct {
 	Value int
 }
 
+func crMustParse(s string) *CronRange {
+	cr, err := ParseString(s)
+	if err != nil {
+		panic(err)
+	}
+	return cr
+}
+
+func crMustParseWithCalendar(s string, cal Calendar, policy HolidayPolicy) *CronRange {
+	cr, err := crMustParse(s).WithCalendar(cal, policy)
+	if err != nil {
+		panic(err)
+	}
+	return cr
+}
+
 func parseLocalTime(s string) time.Time {
 	t, err := time.ParseInLocation("2006-01-02 15:04:05", s, time.Local)
 	if err != nil {
@@ -9,19 +9,54 @@ import (
 	"github.com/robfig/cron/v3"
 )
 
-var (
-	cronParseOption = cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow
-	cronParser      = cron.NewParser(cronParseOption)
+// maxCount is the upper bound of the count limit, since the last activation is found in advance by walking through all of them.
+const maxCount = 10000
 
-	errZeroDuration = errors.New("duration should be positive")
+var (
+	errZeroDuration    = errors.New("duration should be positive")
+	errReversedBounds  = errors.New("not-before bound should not be after not-after bound")
+	errDurationWithEnd = errors.New("duration and end expression should not be both set")
+	errNegativeCount   = errors.New("count should not be negative")
+	errMissStartAt     = errors.New("start time is required by the count limit")
+	errCountTooLarge   = fmt.Errorf("count should not be larger than %d", maxCount)
+	errNilException    = errors.New("exception should not be nil")
 )
 
 // CronRange consists of cron expression along with time zone and duration info.
 type CronRange struct {
 	cronExpression string
 	timeZone       string
+	endExpression  string
 	duration       time.Duration
+	period         period
+	withSeconds    bool
+	notBefore      time.Time
+	notAfter       time.Time
+	count          int
+	startAt        time.Time
+	interval       interval
+	offset         time.Duration
+	exceptions     *exceptionList
+	calendar       Calendar
+	holidayPolicy  HolidayPolicy
+	boundary       Boundary
+	dstMode        DSTMode
+	location       *time.Location
 	schedule       cron.Schedule
+	endSchedule    cron.Schedule
+}
+
+// exceptionList holds the exclusions behind a pointer, so CronRange stays comparable.
+type exceptionList struct {
+	ranges []*CronRange
+}
+
+// exceptionRanges returns the exclusions of the CronRange, or nil if there's none.
+func (cr *CronRange) exceptionRanges() []*CronRange {
+	if cr.exceptions == nil {
+		return nil
+	}
+	return cr.exceptions.ranges
 }
 
 // TimeRange represents a time range between starting time and ending time.
@@ -30,48 +65,151 @@ type TimeRange struct {
 	End   time.Time
 }
 
+// OverlapPolicy controls how the overlapping time ranges are returned, which happen if the duration is longer than the interval of activations.
+type OverlapPolicy uint8
+
+const (
+	// KeepOverlaps returns the overlapping time ranges as they are.
+	KeepOverlaps OverlapPolicy = iota
+	// MergeOverlaps merges the overlapping time ranges into one.
+	MergeOverlaps
+)
+
 // New returns a CronRange instance with given config, time zone can be empty for local time zone.
 //
 // It returns an error if duration is not positive number, or cron expression is invalid, or time zone doesn't exist.
 func New(cronExpr, timeZone string, durationMin uint64) (cr *CronRange, err error) {
+	return NewWithDuration(cronExpr, timeZone, time.Minute*time.Duration(durationMin))
+}
+
+// NewWithDuration returns a CronRange instance with given config like New, but takes the duration as time.Duration,
+// so the time ranges can be measured in seconds or even smaller units.
+//
+// It returns an error if duration is not positive, or cron expression is invalid, or time zone doesn't exist.
+func NewWithDuration(cronExpr, timeZone string, duration time.Duration) (cr *CronRange, err error) {
+	cr = &CronRange{
+		cronExpression: cronExpr,
+		timeZone:       timeZone,
+		duration:       duration,
+	}
+	if err = cr.init(); err != nil {
+		cr = nil
+	}
+	return
+}
+
+// init validates the settings of the CronRange, and compiles the schedule out of them.
+func (cr *CronRange) init() (err error) {
 	// Precondition check
-	if durationMin == 0 {
+	cr.endExpression = strings.TrimSpace(cr.endExpression)
+	hasDuration := cr.duration != 0 || !cr.period.isZero()
+	if cr.endExpression != "" {
+		if hasDuration {
+			err = errDurationWithEnd
+			return
+		}
+	} else if cr.duration < 0 || cr.period.years < 0 || cr.period.months < 0 || cr.period.days < 0 || !hasDuration {
 		err = errZeroDuration
 		return
 	}
 
+	if !cr.notBefore.IsZero() && !cr.notAfter.IsZero() && cr.notBefore.After(cr.notAfter) {
+		err = errReversedBounds
+		return
+	}
+
+	if cr.count < 0 {
+		err = errNegativeCount
+		return
+	} else if cr.count > maxCount {
+		err = errCountTooLarge
+		return
+	} else if cr.count > 0 && cr.startAt.IsZero() {
+		err = errMissStartAt
+		return
+	}
+
+	if (cr.interval.count == 0) != cr.interval.anchor.IsZero() {
+		err = errIntervalAnchorUnpair
+		return
+	}
+
 	// Clean up string parameters
-	cronExpr, timeZone = strings.TrimSpace(cronExpr), strings.TrimSpace(timeZone)
+	cr.cronExpression, cr.timeZone = normalizeCronExpr(cr.cronExpression), strings.TrimSpace(cr.timeZone)
+	if cr.endExpression != "" {
+		cr.endExpression = normalizeCronExpr(cr.endExpression)
+	}
 
-	// Append time zone into cron spec if necessary
-	cronSpec := cronExpr
-	if strings.ToLower(timeZone) == "local" {
-		timeZone = ""
-	} else if len(timeZone) > 0 {
-		cronSpec = fmt.Sprintf("CRON_TZ=%s %s", timeZone, cronExpr)
+	// Load time zone if necessary
+	if strings.ToLower(cr.timeZone) == "local" {
+		cr.timeZone = ""
+	} else if len(cr.timeZone) > 0 {
+		if cr.location, err = time.LoadLocation(cr.timeZone); err != nil {
+			return
+		}
 	}
 
-	// Validate & retrieve crontab schedule
-	var schedule cron.Schedule
-	if schedule, err = cronParser.Parse(cronSpec); err != nil {
+	// Validate & retrieve crontab schedules
+	var schedule, endSchedule cron.Schedule
+	scheduleZone := cr.timeZone
+	if cr.dstMode == WallClockDST {
+		// evaluate on the wall clock given in UTC, and resolve it in the time zone later
+		scheduleZone = ""
+	}
+	if schedule, err = parseSchedule(cr.cronExpression, scheduleZone, cr.withSeconds); err != nil {
 		return
 	}
+	if cr.endExpression != "" {
+		if endSchedule, err = parseSchedule(cr.endExpression, scheduleZone, cr.withSeconds); err != nil {
+			return
+		}
+	}
+	if cr.dstMode == WallClockDST {
+		start := wallSchedule{inner: schedule, loc: cr.location}
+		if endSchedule != nil {
+			endSchedule = wallEndSchedule{start: start, end: wallSchedule{inner: endSchedule, loc: cr.location}, offset: cr.offset}
+		}
+		schedule = start
+	}
+	cr.schedule, cr.endSchedule = schedule, endSchedule
 
-	cr = &CronRange{
-		cronExpression: cronExpr,
-		timeZone:       timeZone,
-		duration:       time.Minute * time.Duration(durationMin),
-		schedule:       schedule,
+	// Filter the activations by the interval from the anchor date
+	if !cr.interval.isZero() {
+		cr.schedule = intervalSchedule{inner: cr.schedule, interval: cr.interval, loc: cr.location}
+	}
+
+	// Skip or shift the time ranges on holidays
+	if cr.calendar != nil {
+		cr.schedule = calendarSchedule{inner: cr.schedule, cal: cr.calendar, policy: cr.holidayPolicy, loc: cr.location}
+	}
+
+	// Shift the time ranges by the offset
+	if cr.offset != 0 {
+		cr.schedule = offsetSchedule{inner: cr.schedule, offset: cr.offset}
+	}
+
+	// Apply the count limit
+	if !cr.startAt.IsZero() {
+		cr.schedule = newCountSchedule(cr.schedule, cr.startAt, cr.count)
 	}
 	return
 }
 
 // Duration returns the duration of the CronRange.
+//
+// For the duration with nominal calendar components like "P1M", only the exact part is returned, and the nominal part is returned by Period().
+// It returns zero if the time ranges end by the end expression instead of a duration.
 func (cr *CronRange) Duration() time.Duration {
 	cr.checkPrecondition()
 	return cr.duration
 }
 
+// Period returns the nominal calendar components of the duration of the CronRange, which are all zero for exact durations.
+func (cr *CronRange) Period() (years, months, days int) {
+	cr.checkPrecondition()
+	return cr.period.years, cr.period.months, cr.period.days
+}
+
 // TimeZone returns the time zone string of the CronRange.
 func (cr *CronRange) TimeZone() string {
 	cr.checkPrecondition()
@@ -83,3 +221,193 @@ func (cr *CronRange) CronExpression() string {
 	cr.checkPrecondition()
 	return cr.cronExpression
 }
+
+// EndExpression returns the Cron expression for the ending time of the time ranges, or empty string if they end by a duration.
+func (cr *CronRange) EndExpression() string {
+	cr.checkPrecondition()
+	return cr.endExpression
+}
+
+// NotBefore returns the not-before bound of the CronRange, the time ranges before it are ignored.
+// It returns the zero time if there's no such bound.
+func (cr *CronRange) NotBefore() time.Time {
+	cr.checkPrecondition()
+	return cr.notBefore
+}
+
+// NotAfter returns the not-after bound of the CronRange, the time ranges after it are ignored.
+// It returns the zero time if there's no such bound.
+func (cr *CronRange) NotAfter() time.Time {
+	cr.checkPrecondition()
+	return cr.notAfter
+}
+
+// WithBounds returns a copy of the CronRange which is only active between the given validity bounds,
+// either of the bounds can be the zero time for no bound on that side.
+//
+// It returns an error if the not-before bound is after the not-after bound.
+func (cr *CronRange) WithBounds(notBefore, notAfter time.Time) (ncr *CronRange, err error) {
+	cr.checkPrecondition()
+	if !notBefore.IsZero() && !notAfter.IsZero() && notBefore.After(notAfter) {
+		err = errReversedBounds
+		return
+	}
+
+	copied := *cr
+	copied.notBefore, copied.notAfter = notBefore, notAfter
+	ncr = &copied
+	return
+}
+
+// CountLimit returns the count limit of occurrences and the time to count from, the count is zero if it's unlimited,
+// and the start time is zero if there's no such limit. The count limit is at most 10000.
+func (cr *CronRange) CountLimit() (count int, start time.Time) {
+	cr.checkPrecondition()
+	return cr.count, cr.startAt
+}
+
+// Offset returns the offset of the starting time from the activation of the cron expression, which is negative for a lead time.
+func (cr *CronRange) Offset() time.Duration {
+	cr.checkPrecondition()
+	return cr.offset
+}
+
+// WithOffset returns a copy of the CronRange with the starting time of each time range shifted from the activation of the cron expression by the offset,
+// e.g. "0 9 * * *" with the offset -30 minutes starts at 08:30 every day.
+func (cr *CronRange) WithOffset(offset time.Duration) (ncr *CronRange, err error) {
+	cr.checkPrecondition()
+	copied := *cr
+	copied.offset = offset
+	if err = copied.init(); err == nil {
+		ncr = &copied
+	}
+	return
+}
+
+// Interval returns the interval like "2w" or "3d" and the anchor date to count it from, the activations of the cron expression
+// are only kept on the first day or week of each interval since the anchor date. It returns empty string and the zero time if there's no interval.
+func (cr *CronRange) Interval() (every string, anchor time.Time) {
+	cr.checkPrecondition()
+	if cr.interval.isZero() {
+		return
+	}
+	return formatInterval(cr.interval.count, cr.interval.weeks), cr.interval.anchor
+}
+
+// WithInterval returns a copy of the CronRange with the interval like "2w" or "3d" counted from the date of anchor,
+// e.g. "0 9 * * 2" with the interval "2w" from a Tuesday stands for every other Tuesday since then.
+//
+// It returns an error if the interval is invalid.
+func (cr *CronRange) WithInterval(every string, anchor time.Time) (ncr *CronRange, err error) {
+	cr.checkPrecondition()
+	copied := *cr
+	if copied.interval.count, copied.interval.weeks, err = parseInterval(every); err != nil {
+		return
+	}
+	year, month, day := anchor.Date()
+	copied.interval.anchor = time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	if err = copied.init(); err == nil {
+		ncr = &copied
+	}
+	return
+}
+
+// Exceptions returns the exclusions of the CronRange, the time ranges covered by any of them are excluded.
+func (cr *CronRange) Exceptions() []*CronRange {
+	cr.checkPrecondition()
+	return cr.exceptionRanges()
+}
+
+// WithExceptions returns a copy of the CronRange with the given exclusions appended, so the time ranges are clipped or dropped
+// if they're covered by any of the exclusions.
+//
+// It returns an error if any of the exclusions is nil.
+func (cr *CronRange) WithExceptions(exceptions ...*CronRange) (ncr *CronRange, err error) {
+	cr.checkPrecondition()
+	for _, ex := range exceptions {
+		if ex == nil {
+			err = errNilException
+			return
+		}
+	}
+
+	copied := *cr
+	copied.exceptions = &exceptionList{ranges: append(append([]*CronRange(nil), cr.exceptionRanges()...), exceptions...)}
+	ncr = &copied
+	return
+}
+
+// Calendar returns the holiday calendar bound to the CronRange and how the time ranges on holidays are treated,
+// the calendar is nil if there's none.
+func (cr *CronRange) Calendar() (cal Calendar, policy HolidayPolicy) {
+	cr.checkPrecondition()
+	return cr.calendar, cr.holidayPolicy
+}
+
+// WithCalendar returns a copy of the CronRange bound to the holiday calendar, so the time ranges starting on holidays
+// in the time zone of the CronRange are skipped or shifted to the next business day according to the policy.
+// The calendar is not a part of the expression returned by String().
+//
+// It returns an error if the calendar is nil.
+func (cr *CronRange) WithCalendar(cal Calendar, policy HolidayPolicy) (ncr *CronRange, err error) {
+	cr.checkPrecondition()
+	if cal == nil {
+		err = errNilCalendar
+		return
+	}
+
+	copied := *cr
+	copied.calendar, copied.holidayPolicy = cal, policy
+	if err = copied.init(); err == nil {
+		ncr = &copied
+	}
+	return
+}
+
+// Boundary returns whether the starting time and ending time are included in the time ranges.
+func (cr *CronRange) Boundary() Boundary {
+	cr.checkPrecondition()
+	return cr.boundary
+}
+
+// WithBoundary returns a copy of the CronRange with the given boundary of time ranges, which is respected by IsWithin() and the likes,
+// e.g. with ClosedOpenBoundary, the moment in between the back-to-back time ranges only falls within the later one.
+//
+// It returns an error if the boundary is invalid.
+func (cr *CronRange) WithBoundary(b Boundary) (ncr *CronRange, err error) {
+	cr.checkPrecondition()
+	if !b.isValid() {
+		err = fmt.Errorf("%w: %v", errInvalidBoundary, b)
+		return
+	}
+
+	copied := *cr
+	copied.boundary = b
+	ncr = &copied
+	return
+}
+
+// DSTMode returns how the time ranges are resolved on the days of DST transitions.
+func (cr *CronRange) DSTMode() DSTMode {
+	cr.checkPrecondition()
+	return cr.dstMode
+}
+
+// WithDSTMode returns a copy of the CronRange with the given DST mode,
+// e.g. with WallClockDST, the time range of 24 hours from midnight always ends at the next midnight in the time zone.
+//
+// It returns an error if the DST mode is invalid.
+func (cr *CronRange) WithDSTMode(m DSTMode) (ncr *CronRange, err error) {
+	cr.checkPrecondition()
+	if !m.isValid() {
+		err = fmt.Errorf("%w: %v", errInvalidDSTMode, m)
+		return
+	}
+
+	copied := *cr
+	copied.dstMode = m
+	if err = copied.init(); err == nil {
+		ncr = &copied
+	}
+	return
+}
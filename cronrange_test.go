@@ -1,6 +1,7 @@
 package cronrange
 
 import (
+	"fmt"
 	"testing"
 	"time"
 )
@@ -20,6 +21,7 @@ func TestNew(t *testing.T) {
 		{"Empty cronExpr", args{emptyString, emptyString, 5}, false, true},
 		{"Invalid cronExpr", args{"h e l l o", emptyString, 5}, false, true},
 		{"Incomplete cronExpr", args{"* * * *", emptyString, 5}, false, true},
+		{"Every descriptor with time zone prefix", args{"TZ=UTC @every 5m", emptyString, 60}, false, true},
 		{"Nonexistent time zone", args{exprEveryMin, "Mars", 5}, false, true},
 		{"Zero durationMin", args{exprEveryMin, emptyString, 0}, false, true},
 		{"Normal without time zone", args{exprEveryMin, emptyString, 5}, true, false},
@@ -49,6 +51,40 @@ func BenchmarkNew(b *testing.B) {
 	}
 }
 
+func TestNewWithDuration(t *testing.T) {
+	type args struct {
+		cronExpr string
+		timeZone string
+		duration time.Duration
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantCr  string
+		wantErr bool
+	}{
+		{"Zero duration", args{exprEveryMin, emptyString, 0}, emptyString, true},
+		{"Negative duration", args{exprEveryMin, emptyString, -time.Minute}, emptyString, true},
+		{"Invalid cronExpr", args{"h e l l o", emptyString, time.Minute}, emptyString, true},
+		{"Nonexistent time zone", args{exprEveryMin, "Mars", time.Minute}, emptyString, true},
+		{"Whole minutes", args{exprEveryMin, emptyString, time.Hour}, "DR=60; * * * * *", false},
+		{"Seconds", args{exprEveryMin, emptyString, 30 * time.Second}, "DR=30s; * * * * *", false},
+		{"Minutes with seconds in Tokyo", args{exprEveryNewYear, timeZoneTokyo, 90 * time.Second}, "DR=1m30s; TZ=Asia/Tokyo; 0 0 1 1 *", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotCr, err := NewWithDuration(tt.args.cronExpr, tt.args.timeZone, tt.args.duration)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewWithDuration() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && gotCr.String() != tt.wantCr {
+				t.Errorf("NewWithDuration() gotCr = %v, wantCr %v", gotCr, tt.wantCr)
+			}
+		})
+	}
+}
+
 func TestCronRange_Duration(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -138,6 +174,8 @@ func TestCronRange_CronExpression(t *testing.T) {
 		{"Every Xmas morning in NYC", crEveryXmasMorningNYC, "0 8 25 12 *", false},
 		{"Every New Year's Day in Tokyo", crEveryNewYearsDayTokyo, "0 0 1 1 *", false},
 		{"Every the 3rd day in Honolulu", crThirdDayEachMonthHonolulu, "0 0 3 * *", false},
+		{"Every day by descriptor", crMustParse("DR=60; @daily"), "@daily", false},
+		{"Every 90 minutes by descriptor", crMustParse("DR=30; @every 1h30m"), "@every 1h30m", false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -160,3 +198,342 @@ func BenchmarkCronRange_CronExpression(b *testing.B) {
 		_ = crEveryNewYearsDayBangkok.CronExpression()
 	}
 }
+
+func TestCronRange_WithBounds(t *testing.T) {
+	notBefore, notAfter := parseTime(locationUTC, "2020-01-02 00:00:00"), parseTime(locationUTC, "2020-01-03 00:00:00")
+	tests := []struct {
+		name      string
+		cr        *CronRange
+		notBefore time.Time
+		notAfter  time.Time
+		wantS     string
+		wantErr   bool
+	}{
+		{"Nil struct", crNil, notBefore, notAfter, emptyString, true},
+		{"Empty struct", crEmpty, notBefore, notAfter, emptyString, true},
+		{"Reversed bounds", crEvery1Min, notAfter, notBefore, emptyString, true},
+		{"No bounds", crEvery1Min, zeroTime, zeroTime, "DR=1; * * * * *", false},
+		{"Not-before bound only", crEvery1Min, notBefore, zeroTime, "DR=1; NB=2020-01-02T00:00:00Z; * * * * *", false},
+		{"Both bounds", crEveryNewYearsDayTokyo, notBefore, notAfter, "DR=1440; TZ=Asia/Tokyo; NB=2020-01-02T00:00:00Z; NA=2020-01-03T00:00:00Z; 0 0 1 1 *", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				got *CronRange
+				err error
+			)
+			func() {
+				defer func() {
+					if r := recover(); r != nil {
+						err = fmt.Errorf("panic: %v", r)
+					}
+				}()
+				got, err = tt.cr.WithBounds(tt.notBefore, tt.notAfter)
+			}()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("WithBounds() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.String() != tt.wantS {
+				t.Errorf("WithBounds() got = %v, want %v", got, tt.wantS)
+			}
+			if !got.NotBefore().Equal(tt.notBefore) || !got.NotAfter().Equal(tt.notAfter) {
+				t.Errorf("WithBounds() got bounds = %v, %v, want %v, %v", got.NotBefore(), got.NotAfter(), tt.notBefore, tt.notAfter)
+			}
+			if got == tt.cr || tt.cr.NotBefore() != zeroTime {
+				t.Errorf("WithBounds() changed the original instance")
+			}
+		})
+	}
+}
+
+func TestCronRange_WithExceptions(t *testing.T) {
+	crFirstMonday := crMustParse("DR=1440; 0 0 * * 1#1")
+	tests := []struct {
+		name       string
+		cr         *CronRange
+		exceptions []*CronRange
+		wantS      string
+		wantErr    bool
+	}{
+		{"Nil struct", crNil, []*CronRange{crFirstMonday}, emptyString, true},
+		{"Empty struct", crEmpty, []*CronRange{crFirstMonday}, emptyString, true},
+		{"Nil exception", crEvery1Min, []*CronRange{crFirstMonday, nil}, emptyString, true},
+		{"No exceptions", crEvery1Min, nil, "DR=1; * * * * *", false},
+		{"Single exception", crEvery1Min, []*CronRange{crFirstMonday}, "DR=1; EXCEPT=[DR=1440; 0 0 * * 1#1]; * * * * *", false},
+		{"Appended exceptions", crMustParse("DR=1; EXCEPT=[DR=60; 0 12 * * *]; * * * * *"), []*CronRange{crFirstMonday}, "DR=1; EXCEPT=[DR=60; 0 12 * * *]; EXCEPT=[DR=1440; 0 0 * * 1#1]; * * * * *", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				got *CronRange
+				err error
+			)
+			func() {
+				defer func() {
+					if r := recover(); r != nil {
+						err = fmt.Errorf("panic: %v", r)
+					}
+				}()
+				got, err = tt.cr.WithExceptions(tt.exceptions...)
+			}()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("WithExceptions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.String() != tt.wantS {
+				t.Errorf("WithExceptions() got = %v, want %v", got, tt.wantS)
+			}
+			if len(got.Exceptions()) != len(tt.cr.Exceptions())+len(tt.exceptions) {
+				t.Errorf("WithExceptions() got %d exceptions, want %d", len(got.Exceptions()), len(tt.cr.Exceptions())+len(tt.exceptions))
+			}
+		})
+	}
+}
+
+func TestCronRange_WithCalendar(t *testing.T) {
+	cal := NewDateCalendar(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	tests := []struct {
+		name    string
+		cr      *CronRange
+		cal     Calendar
+		policy  HolidayPolicy
+		wantErr bool
+	}{
+		{"Nil struct", crNil, cal, SkipHoliday, true},
+		{"Empty struct", crEmpty, cal, SkipHoliday, true},
+		{"Nil calendar", crEvery1Min, nil, SkipHoliday, true},
+		{"Skip holidays", crEveryNewYearsDayTokyo, cal, SkipHoliday, false},
+		{"Shift holidays", crEveryNewYearsDayTokyo, cal, ShiftHoliday, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				got *CronRange
+				err error
+			)
+			func() {
+				defer func() {
+					if r := recover(); r != nil {
+						err = fmt.Errorf("panic: %v", r)
+					}
+				}()
+				got, err = tt.cr.WithCalendar(tt.cal, tt.policy)
+			}()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("WithCalendar() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if gotCal, gotPolicy := got.Calendar(); gotCal != tt.cal || gotPolicy != tt.policy {
+				t.Errorf("WithCalendar() got = %v, %v, want %v, %v", gotCal, gotPolicy, tt.cal, tt.policy)
+			}
+			if got.String() != tt.cr.String() {
+				t.Errorf("WithCalendar() got expression = %v, want %v", got, tt.cr)
+			}
+			if origCal, _ := tt.cr.Calendar(); origCal != nil {
+				t.Errorf("WithCalendar() changed the original")
+			}
+		})
+	}
+}
+
+func TestCronRange_WithInterval(t *testing.T) {
+	anchor := time.Date(2025, 1, 7, 23, 0, 0, 0, locationTokyo)
+	tests := []struct {
+		name      string
+		cr        *CronRange
+		every     string
+		wantEvery string
+		wantS     string
+		wantErr   bool
+	}{
+		{"Nil struct", crNil, "2w", emptyString, emptyString, true},
+		{"Empty struct", crEmpty, "2w", emptyString, emptyString, true},
+		{"Invalid interval", crEvery1Min, "2", emptyString, emptyString, true},
+		{"Every other week", crMustParse("DR=60; 0 9 * * 2"), "2w", "2w", "DR=60; EVERY=2w; ANCHOR=2025-01-07; 0 9 * * 2", false},
+		{"Every 3 days", crMustParse("DR=60; TZ=Asia/Tokyo; 0 9 * * *"), "3d", "3d", "DR=60; TZ=Asia/Tokyo; EVERY=3d; ANCHOR=2025-01-07; 0 9 * * *", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				got *CronRange
+				err error
+			)
+			func() {
+				defer func() {
+					if r := recover(); r != nil {
+						err = fmt.Errorf("panic: %v", r)
+					}
+				}()
+				got, err = tt.cr.WithInterval(tt.every, anchor)
+			}()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("WithInterval() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if gotEvery, gotAnchor := got.Interval(); gotEvery != tt.wantEvery || gotAnchor.Format(strDateLayout) != "2025-01-07" {
+				t.Errorf("WithInterval() got = %v, %v, want %v", gotEvery, gotAnchor, tt.wantEvery)
+			}
+			if got.String() != tt.wantS {
+				t.Errorf("WithInterval() got = %v, want %v", got, tt.wantS)
+			}
+		})
+	}
+}
+
+func TestCronRange_WithOffset(t *testing.T) {
+	tests := []struct {
+		name    string
+		cr      *CronRange
+		offset  time.Duration
+		wantS   string
+		wantErr bool
+	}{
+		{"Nil struct", crNil, time.Hour, emptyString, true},
+		{"Empty struct", crEmpty, time.Hour, emptyString, true},
+		{"Zero offset", crEvery5Min, 0, "DR=5; */5 * * * *", false},
+		{"Lead time", crEveryXmasMorningNYC, -90 * time.Minute, "DR=240; TZ=America/New_York; OFF=-90; 0 8 25 12 *", false},
+		{"Delay in seconds", crEveryXmasMorningNYC, 30 * time.Second, "DR=240; TZ=America/New_York; OFF=30s; 0 8 25 12 *", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				got *CronRange
+				err error
+			)
+			func() {
+				defer func() {
+					if r := recover(); r != nil {
+						err = fmt.Errorf("panic: %v", r)
+					}
+				}()
+				got, err = tt.cr.WithOffset(tt.offset)
+			}()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("WithOffset() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Offset() != tt.offset || got.String() != tt.wantS {
+				t.Errorf("WithOffset() got = %v, %v, want %v, %v", got.Offset(), got, tt.offset, tt.wantS)
+			}
+		})
+	}
+}
+
+func TestCronRange_WithBoundary(t *testing.T) {
+	tests := []struct {
+		name     string
+		cr       *CronRange
+		boundary Boundary
+		wantS    string
+		wantErr  bool
+	}{
+		{"Nil struct", crNil, ClosedOpenBoundary, emptyString, true},
+		{"Empty struct", crEmpty, ClosedOpenBoundary, emptyString, true},
+		{"Invalid boundary", crEvery5Min, Boundary(4), emptyString, true},
+		{"Closed", crEvery5Min, ClosedBoundary, "DR=5; */5 * * * *", false},
+		{"Closed-open", crEvery5Min, ClosedOpenBoundary, "DR=5; BD=closed-open; */5 * * * *", false},
+		{"Open-closed", crEveryXmasMorningNYC, OpenClosedBoundary, "DR=240; TZ=America/New_York; BD=open-closed; 0 8 25 12 *", false},
+		{"Open", crEveryXmasMorningNYC, OpenBoundary, "DR=240; TZ=America/New_York; BD=open; 0 8 25 12 *", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				got *CronRange
+				err error
+			)
+			func() {
+				defer func() {
+					if r := recover(); r != nil {
+						err = fmt.Errorf("panic: %v", r)
+					}
+				}()
+				got, err = tt.cr.WithBoundary(tt.boundary)
+			}()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("WithBoundary() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Boundary() != tt.boundary || got.String() != tt.wantS {
+				t.Errorf("WithBoundary() got = %v, %v, want %v, %v", got.Boundary(), got, tt.boundary, tt.wantS)
+			}
+		})
+	}
+}
+
+func TestCronRange_WithDSTMode(t *testing.T) {
+	tests := []struct {
+		name    string
+		cr      *CronRange
+		mode    DSTMode
+		wantS   string
+		wantErr bool
+	}{
+		{"Nil struct", crNil, WallClockDST, emptyString, true},
+		{"Empty struct", crEmpty, WallClockDST, emptyString, true},
+		{"Invalid mode", crEvery5Min, DSTMode(2), emptyString, true},
+		{"Absolute", crEvery5Min, AbsoluteDST, "DR=5; */5 * * * *", false},
+		{"Wall clock", crEvery5Min, WallClockDST, "DR=5; DST=wall; */5 * * * *", false},
+		{"Wall clock with time zone", crEveryXmasMorningNYC, WallClockDST, "DR=240; TZ=America/New_York; DST=wall; 0 8 25 12 *", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				got *CronRange
+				err error
+			)
+			func() {
+				defer func() {
+					if r := recover(); r != nil {
+						err = fmt.Errorf("panic: %v", r)
+					}
+				}()
+				got, err = tt.cr.WithDSTMode(tt.mode)
+			}()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("WithDSTMode() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.DSTMode() != tt.mode || got.String() != tt.wantS {
+				t.Errorf("WithDSTMode() got = %v, %v, want %v, %v", got.DSTMode(), got, tt.mode, tt.wantS)
+			}
+		})
+	}
+}
+
+func TestCronRange_Comparable(t *testing.T) {
+	cr := crMustParse("DR=480; TZ=Etc/UTC; EXCEPT=[DR=60; TZ=Etc/UTC; 0 12 * * *]; 0 9 * * *")
+	copied := *cr
+	if copied != *cr {
+		t.Errorf("copied CronRange = %v, want equal to %v", copied, cr)
+	}
+
+	seen := map[CronRange]bool{*cr: true}
+	if !seen[copied] {
+		t.Errorf("CronRange as map key got no match for %v", copied)
+	}
+	if other := crMustParse("DR=480; TZ=Etc/UTC; 0 9 * * *"); *other == *cr {
+		t.Errorf("CronRange without exceptions = %v, want not equal to %v", other, cr)
+	}
+}
@@ -15,5 +15,78 @@ It consists of three parts separated by a semicolon:
     - `TZ=Asia/Tokyo` is optional and for time zone using name in IANA Time Zone database (https://www.iana.org/time-zones);
     - `0 0 1 1 *` is a cron expression representing the beginning moment of the time range.
 
+Besides the plain number of minutes, the duration can be written with units like `90s`, `1h30m` or `2d`,
+or with calendar units like `1w`, `1mo` and `1y`, or in ISO 8601 format like `PT2H30M` or `P1M`.
+The calendar units, as well as the nominal years, months, weeks and days in ISO 8601 format, are measured on the calendar of the time zone,
+e.g. `DR=1mo; 0 0 1 * *` lasts for the whole month no matter how many days it has.
+
+Instead of the duration, an `END=` part can set a cron expression for the ending time, so each time range ends at the first activation of it after the start,
+e.g. `END=0 6 * * 1; TZ=Europe/Berlin; 0 18 * * 5` stands for Friday 18:00 until Monday 06:00 in Berlin, regardless of DST changes.
+
+Multiple cron expressions separated by `|` share the duration and time zone, and their time ranges are merged in chronological order,
+e.g. `DR=120; 0 9 * * 1-5 | 0 11 * * 0,6` starts at 09:00 on weekdays and 11:00 on weekends.
+
+A wall-clock time window with optional days of week can replace the cron expression, e.g. `TZ=Europe/Berlin; Mon-Fri 09:00-17:00` for the business hours,
+or `22:00-06:00` for the overnight window, it's compiled into the cron expression and `END=` part, and `StringWith(Shorthand)` formats it back.
+
+An optional `SEC=1` part enables the seconds field at the beginning of the cron expression, e.g. `DR=5; SEC=1; 30 0 9 * * *` starts at 09:00:30 every day.
+
+Descriptors like `@daily`, `@weekly` or `@monthly` can be used in place of the cron expression,
+and `@every 90m` activates at every 90 minutes counted from the Unix epoch, i.e. 1970-01-01T00:00:00Z.
+
+Named date rules of movable feasts and holidays activate at the midnight of the date, with an optional offset of days,
+e.g. `DR=1440; TZ=Europe/Berlin; @easter-2` stands for Good Friday. Valid names are `@easter`, `@orthodox-easter`, `@thanksgiving`, `@memorial-day`,
+`@labor-day`, `@mothers-day` and `@fathers-day`, the last five are US holidays.
+
+Quartz-style operators are supported in day-of-month and day-of-week fields: `L` for the last day of month, `L-3` for the third to last day,
+`LW` for the last weekday, `15W` for the weekday nearest to the 15th, `5L` for the last Friday, and `5#3` for the third Friday of the month.
+An optional year field can follow the day-of-week field, e.g. `0 0 28 11 * 2025-2027` only activates in 2025 to 2027.
+
+Optional `NB=` and `NA=` parts in RFC 3339 format set the validity bounds, e.g. `DR=60; NB=2025-01-01T00:00:00Z; NA=2025-03-31T23:59:59Z; 0 9 * * *`,
+the time ranges are clipped by the bounds, and ignored if they're out of bounds.
+And an optional `START=` part ignores the time ranges starting before it, along with which an optional `CNT=` part limits the number of time ranges,
+e.g. `DR=15; CNT=10; START=2025-01-06T00:00:00Z; 0 9 * * 1` stands for the first 10 Monday standups since 2025-01-06. The count is at most 10000.
+
+An optional `EVERY=` part along with an `ANCHOR=` part keeps the activations on the first day or week of each interval counted from the anchor date and drops the ones before it,
+e.g. `DR=60; EVERY=2w; ANCHOR=2025-01-07; 0 9 * * 2` stands for every other Tuesday since 2025-01-07, and `EVERY=3d` for every 3 days.
+
+An optional `OFF=` part shifts the starting time of each time range from the activation by a signed duration,
+e.g. `DR=45; OFF=-30m; 0 9 * * *` starts 30 minutes before 09:00 every day.
+
+An optional `BD=` part sets whether the starting time and ending time are included in the time ranges, which can be `closed` (default), `closed-open`,
+`open-closed` or `open`, e.g. with `DR=60; BD=closed-open; 0 * * * *`, the top of the hour only falls within the time range starting at it.
+The same boundary can be passed to the Contains() and Overlaps() methods of TimeRange.
+
+An optional `DST=` part sets how the time ranges are resolved on the days of DST transitions, which can be `abs` (default) or `wall`.
+With `abs`, the duration is the exact elapsed time, so `DR=1440; TZ=Europe/Berlin; 0 0 * * *` ends at 01:00 or 23:00 on those days,
+the activations in a spring-forward gap are skipped, and the ones in a repeated fall-back hour happen twice.
+With `wall`, the cron expression and duration are applied on the wall clock in the time zone, so the same time range always ends at the next midnight,
+the wall clock skipped by a spring-forward gap is shifted forward by the length of the gap, e.g. 02:30 becomes 03:30,
+and the wall clock repeated by a fall-back transition resolves to its first occurrence. The `OFF=` part is always the exact elapsed time.
+
+Optional `EXCEPT=` parts set the exclusions in CronRange expressions enclosed in square brackets, each of which has its own time zone,
+e.g. `DR=480; EXCEPT=[DR=1440; 0 0 * * 1#1]; 0 9 * * 1-5` stands for the business hours except the first Monday of each month,
+the time ranges are clipped or dropped if they're covered by any of the exclusions.
+The moments where a time range is clipped belong to the exclusion if its boundary contains them, so they're not within the CronRange.
+
+A holiday calendar can be bound to the CronRange with WithCalendar(), so the time ranges starting on holidays are skipped or shifted to the next business day,
+and DateCalendar is an in-memory calendar loaded from a list of dates like `2025-12-25`. The calendar is not a part of the expression.
+
+Beyond the IsWithin() check, CurrentOccurrence() returns the time range containing the moment,
+and Remaining() and Elapsed() return the durations from the moment to its end and from its start to the moment.
+The time ranges overlap if the duration is longer than the interval of activations, e.g. `DR=120; 0,30 * * * *`,
+the moment is within if any of them contains it, and ContainingOccurrences() returns all of them, or merged into one with MergeOverlaps.
+
+Besides looking forward with NextOccurrences(), the previous time ranges can be found backward with PreviousOccurrences() and LastOccurrence(),
+which search the Cron fields in reverse and give the same time ranges as the forward search across DST transitions.
+And OccurrencesBetween() returns all the time ranges overlapping with an interval, including the one still running at the beginning of it,
+with an optional cap on the number of time ranges.
+To stream the time ranges without a fixed count, Iterator() returns an OccurrenceIterator walking forward and backward lazily,
+and Occurrences() returns an iter.Seq for the range loops in Go 1.23 or later.
+
+Multiple CronRange instances can be combined into a CronRangeSet with operators `|` for union, `&` for intersection and `-` for difference,
+e.g. `[DR=480; 0 9 * * 1-5] - [DR=60; 0 12 * * *]` stands for the business hours except the lunch break,
+the operators are evaluated from left to right, and parentheses can be used for grouping.
+
 */
 package cronrange
@@ -0,0 +1,188 @@
+package cronrange
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+var (
+	errInvalidDSTMode = errors.New("DST mode should be either abs or wall")
+)
+
+// maxDSTShift is the upper bound of the shift of wall clock by a DST transition.
+const maxDSTShift = 3 * time.Hour
+
+// DSTMode controls how the time ranges are resolved on the days of DST transitions.
+type DSTMode uint8
+
+const (
+	// AbsoluteDST applies the exact duration as the elapsed time, e.g. the time range of 24 hours from midnight ends at 01:00 or 23:00 on the days of DST transitions,
+	// and the cron expression activates as in the time zone, i.e. the activations in a spring-forward gap are skipped,
+	// and the ones in a repeated fall-back hour happen twice. It's the default.
+	AbsoluteDST DSTMode = iota
+	// WallClockDST evaluates the cron expression and applies the exact duration on the wall clock in the time zone,
+	// e.g. the time range of 24 hours from midnight always ends at the next midnight.
+	// The wall clock skipped by a spring-forward gap is shifted forward by the length of the gap, e.g. 02:30 becomes 03:30 if the clock jumps from 02:00 to 03:00,
+	// and the wall clock repeated by a fall-back transition resolves to its first occurrence, so each activation on the wall clock happens exactly once.
+	WallClockDST
+)
+
+var dstModeNames = [...]string{
+	AbsoluteDST:  "abs",
+	WallClockDST: "wall",
+}
+
+// String returns the name of the DST mode used in DST= part, e.g. "wall".
+func (m DSTMode) String() string {
+	if m.isValid() {
+		return dstModeNames[m]
+	}
+	return fmt.Sprintf("DSTMode(%d)", m)
+}
+
+func (m DSTMode) isValid() bool {
+	return int(m) < len(dstModeNames)
+}
+
+// parseDSTMode parses the value of DST= part like "wall".
+func parseDSTMode(s string) (DSTMode, error) {
+	for m, name := range dstModeNames {
+		if s == name {
+			return DSTMode(m), nil
+		}
+	}
+	return AbsoluteDST, fmt.Errorf("%w: %q", errInvalidDSTMode, s)
+}
+
+// wallClockOf returns the wall clock of the given time in the location, as the same clock in UTC.
+func wallClockOf(t time.Time, loc *time.Location) time.Time {
+	local := t.In(loc)
+	year, month, day := local.Date()
+	hour, min, sec := local.Clock()
+	return time.Date(year, month, day, hour, min, sec, local.Nanosecond(), time.UTC)
+}
+
+// resolveWallClock returns the time of the wall clock given in UTC in the location,
+// the wall clock skipped by a spring-forward gap is shifted forward by the length of the gap,
+// and the wall clock repeated by a fall-back transition resolves to its first occurrence.
+func resolveWallClock(wall time.Time, loc *time.Location) time.Time {
+	_, offBefore := wall.Add(-24 * time.Hour).In(loc).Zone()
+	_, offAfter := wall.Add(24 * time.Hour).In(loc).Zone()
+	early, late := wall.Add(-time.Duration(offBefore)*time.Second), wall.Add(-time.Duration(offAfter)*time.Second)
+	if late.Before(early) {
+		early, late = late, early
+	}
+	for _, t := range []time.Time{early, late} {
+		if wallClockOf(t, loc).Equal(wall) {
+			return t.In(loc)
+		}
+	}
+
+	// it's in the gap, so take the offset before the transition, which shifts it forward
+	return wall.Add(-time.Duration(offBefore) * time.Second).In(loc)
+}
+
+// nearDSTShift checks if the offset of the location changes within maxDSTShift around the given time.
+func nearDSTShift(t time.Time, loc *time.Location) bool {
+	_, offBefore := t.Add(-maxDSTShift).In(loc).Zone()
+	_, offAfter := t.Add(maxDSTShift).In(loc).Zone()
+	return offBefore != offAfter
+}
+
+// wallSchedule evaluates the inner schedule on the wall clock of the location or the location of given time if it's nil,
+// and resolves the activations with resolveWallClock(). The inner schedule should take the time in UTC as the wall clock.
+type wallSchedule struct {
+	inner cron.Schedule
+	loc   *time.Location
+}
+
+// Next returns the next activation time, later than the given time. It returns the zero time if the inner schedule does.
+func (s wallSchedule) Next(t time.Time) time.Time {
+	loc := s.loc
+	if loc == nil {
+		loc = t.Location()
+	}
+
+	from := wallClockOf(t, loc)
+	if nearDSTShift(t, loc) {
+		// the wall clock shifted forward from the gap may be later than t
+		from = from.Add(-maxDSTShift)
+	}
+	for next := s.inner.Next(from); !next.IsZero(); next = s.inner.Next(next) {
+		if resolved := resolveWallClock(next, loc); resolved.After(t) {
+			return resolved.In(t.Location())
+		}
+	}
+	return time.Time{}
+}
+
+// Prev returns the previous activation time, earlier than the given time. It returns the zero time if the inner schedule does.
+func (s wallSchedule) Prev(t time.Time) time.Time {
+	loc := s.loc
+	if loc == nil {
+		loc = t.Location()
+	}
+
+	from := wallClockOf(t, loc)
+	if nearDSTShift(t, loc) {
+		// the wall clock repeated by the fall-back transition may be earlier than t
+		from = from.Add(maxDSTShift)
+	}
+	for prev := prevActivation(s.inner, from); !prev.IsZero(); prev = prevActivation(s.inner, prev) {
+		if resolved := resolveWallClock(prev, loc); resolved.Before(t) {
+			return resolved.In(t.Location())
+		}
+	}
+	return time.Time{}
+}
+
+// origin returns the activation of the inner schedule on the wall clock which is resolved to the given time,
+// i.e. the wall clock before shifted forward from a spring-forward gap, or the wall clock of the given time if there's no such activation.
+func (s wallSchedule) origin(t time.Time) time.Time {
+	loc := s.loc
+	if loc == nil {
+		loc = t.Location()
+	}
+
+	wall := wallClockOf(t, loc)
+	if s.inner.Next(wall.Add(-time.Nanosecond)).Equal(wall) || !nearDSTShift(t, loc) {
+		return wall
+	}
+	if prev := prevActivation(s.inner, wall); !prev.IsZero() && resolveWallClock(prev, loc).Equal(t) {
+		return prev
+	}
+	return wall
+}
+
+// wallEndSchedule finds the ending time of the time range starting at the given time on the wall clock,
+// the first activation of the end schedule after the wall clock of the start schedule's activation, which is before shifted by a spring-forward gap,
+// so the time range starting in the gap keeps its length on the wall clock, and shifts forward along with the start.
+type wallEndSchedule struct {
+	start  wallSchedule
+	end    wallSchedule
+	offset time.Duration
+}
+
+// Next returns the ending time of the time range starting at the given time, or the zero time if the end schedule finds none.
+func (s wallEndSchedule) Next(t time.Time) time.Time {
+	loc := s.end.loc
+	if loc == nil {
+		loc = t.Location()
+	}
+
+	// the starting time is shifted by the offset from the activation
+	origin := s.start.origin(t.Add(-s.offset)).Add(s.offset)
+	next := s.end.inner.Next(origin)
+	if next.IsZero() {
+		return next
+	}
+	if end := resolveWallClock(next, loc); end.After(t) {
+		return end.In(t.Location())
+	}
+
+	// the start is shifted forward from the gap beyond the end, so shift the end as well
+	return resolveWallClock(wallClockOf(t, loc).Add(next.Sub(origin)), loc).In(t.Location())
+}
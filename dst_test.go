@@ -0,0 +1,272 @@
+package cronrange
+
+import (
+	"testing"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+func TestParseDSTMode(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    DSTMode
+		wantErr bool
+	}{
+		{"abs", AbsoluteDST, false},
+		{"wall", WallClockDST, false},
+		{"", AbsoluteDST, true},
+		{"Wall", AbsoluteDST, true},
+		{"local", AbsoluteDST, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			got, err := parseDSTMode(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseDSTMode() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("parseDSTMode() got = %v, want %v", got, tt.want)
+			}
+			if !tt.wantErr && got.String() != tt.s {
+				t.Errorf("String() got = %v, want %v", got.String(), tt.s)
+			}
+		})
+	}
+	if s := DSTMode(7).String(); s != "DSTMode(7)" {
+		t.Errorf("String() got = %v, want DSTMode(7)", s)
+	}
+}
+
+func TestResolveWallClock(t *testing.T) {
+	locationBerlin, _ := time.LoadLocation("Europe/Berlin")
+	locationNewYork, _ := time.LoadLocation(timeZoneNewYork)
+	tests := []struct {
+		name string
+		loc  *time.Location
+		wall string
+		want string
+	}{
+		{"Normal in winter", locationBerlin, "2020-03-28 12:00:00", "2020-03-28 11:00:00"},
+		{"Normal in summer", locationBerlin, "2020-03-29 12:00:00", "2020-03-29 10:00:00"},
+		{"Right before the gap", locationBerlin, "2020-03-29 01:59:59", "2020-03-29 00:59:59"},
+		{"Beginning of the gap", locationBerlin, "2020-03-29 02:00:00", "2020-03-29 01:00:00"},
+		{"Within the gap", locationBerlin, "2020-03-29 02:30:00", "2020-03-29 01:30:00"},
+		{"Right after the gap", locationBerlin, "2020-03-29 03:00:00", "2020-03-29 01:00:00"},
+		{"Late evening before the gap", locationBerlin, "2020-03-28 23:00:00", "2020-03-28 22:00:00"},
+		{"Right before the repeated hour", locationBerlin, "2020-10-25 01:59:00", "2020-10-24 23:59:00"},
+		{"Beginning of the repeated hour", locationBerlin, "2020-10-25 02:00:00", "2020-10-25 00:00:00"},
+		{"Within the repeated hour", locationBerlin, "2020-10-25 02:30:00", "2020-10-25 00:30:00"},
+		{"Right after the repeated hour", locationBerlin, "2020-10-25 03:00:00", "2020-10-25 02:00:00"},
+		{"Late evening before the repeated hour", locationBerlin, "2020-10-24 23:00:00", "2020-10-24 21:00:00"},
+		{"Within the gap in New York", locationNewYork, "2020-03-08 02:30:00", "2020-03-08 07:30:00"},
+		{"Within the repeated hour in New York", locationNewYork, "2020-11-01 01:30:00", "2020-11-01 05:30:00"},
+		{"No DST in UTC", locationUTC, "2020-03-29 02:30:00", "2020-03-29 02:30:00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := resolveWallClock(parseTime(locationUTC, tt.wall), tt.loc)
+			if want := parseTime(locationUTC, tt.want); !got.Equal(want) {
+				t.Errorf("resolveWallClock() = %v, want %v", got, want.In(tt.loc))
+			}
+			if got.Location() != tt.loc {
+				t.Errorf("resolveWallClock() location = %v, want %v", got.Location(), tt.loc)
+			}
+		})
+	}
+}
+
+func TestWallSchedule(t *testing.T) {
+	locationBerlin, _ := time.LoadLocation("Europe/Berlin")
+	tests := []struct {
+		name     string
+		expr     string
+		t        string
+		wantNext string
+		wantPrev string
+	}{
+		{"Daily before the gap", "30 2 * * *", "2020-03-28 11:00:00", "2020-03-29 01:30:00", "2020-03-28 01:30:00"},
+		{"Daily within the shifted hour", "30 2 * * *", "2020-03-29 01:00:00", "2020-03-29 01:30:00", "2020-03-28 01:30:00"},
+		{"Daily at the shifted activation", "30 2 * * *", "2020-03-29 01:30:00", "2020-03-30 00:30:00", "2020-03-28 01:30:00"},
+		{"Daily after the gap", "30 2 * * *", "2020-03-29 22:00:00", "2020-03-30 00:30:00", "2020-03-29 01:30:00"},
+		{"Daily before the repeated hour", "30 2 * * *", "2020-10-24 10:00:00", "2020-10-25 00:30:00", "2020-10-24 00:30:00"},
+		{"Daily at the first of the repeated hour", "30 2 * * *", "2020-10-25 00:30:00", "2020-10-26 01:30:00", "2020-10-24 00:30:00"},
+		{"Daily within the second of the repeated hour", "30 2 * * *", "2020-10-25 01:45:00", "2020-10-26 01:30:00", "2020-10-25 00:30:00"},
+		{"Hourly before the gap", "0 * * * *", "2020-03-29 00:30:00", "2020-03-29 01:00:00", "2020-03-29 00:00:00"},
+		{"Hourly at the merged activation", "0 * * * *", "2020-03-29 01:00:00", "2020-03-29 02:00:00", "2020-03-29 00:00:00"},
+		{"Hourly within the repeated hour", "0 * * * *", "2020-10-25 01:30:00", "2020-10-25 02:00:00", "2020-10-25 00:00:00"},
+		{"Hourly after the repeated hour", "0 * * * *", "2020-10-25 02:00:00", "2020-10-25 03:00:00", "2020-10-25 00:00:00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inner, err := cron.ParseStandard(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseStandard() error = %v", err)
+			}
+			s := wallSchedule{inner: inner, loc: locationBerlin}
+			at := parseTime(locationUTC, tt.t).In(locationBerlin)
+			if got, want := s.Next(at), parseTime(locationUTC, tt.wantNext); !got.Equal(want) {
+				t.Errorf("Next() = %v, want %v", got, want.In(locationBerlin))
+			}
+			if got, want := s.Prev(at), parseTime(locationUTC, tt.wantPrev); !got.Equal(want) {
+				t.Errorf("Prev() = %v, want %v", got, want.In(locationBerlin))
+			}
+		})
+	}
+}
+
+func TestWallSchedule_ReverseOfNext(t *testing.T) {
+	locationBerlin, _ := time.LoadLocation("Europe/Berlin")
+	locationNewYork, _ := time.LoadLocation(timeZoneNewYork)
+	exprs := []string{
+		"*/7 * * * *",
+		"30 2 * * *",
+		"0 0 * * *",
+		"15 1-3 * * 0",
+	}
+	ranges := []struct {
+		loc   *time.Location
+		start string
+		end   string
+	}{
+		{locationBerlin, "2020-03-28 00:00:00", "2020-03-30 00:00:00"},
+		{locationBerlin, "2020-10-24 00:00:00", "2020-10-26 00:00:00"},
+		{locationNewYork, "2020-03-07 00:00:00", "2020-03-09 00:00:00"},
+		{locationNewYork, "2020-10-31 00:00:00", "2020-11-02 00:00:00"},
+	}
+	for _, expr := range exprs {
+		inner, err := cron.ParseStandard(expr)
+		if err != nil {
+			t.Fatalf("ParseStandard(%q) error = %v", expr, err)
+		}
+		for _, r := range ranges {
+			s := wallSchedule{inner: inner, loc: r.loc}
+			start, end := parseTime(r.loc, r.start), parseTime(r.loc, r.end)
+			var forward []time.Time
+			for next := s.Next(start.Add(-time.Nanosecond)); next.Before(end); next = s.Next(next) {
+				forward = append(forward, next)
+			}
+			var backward []time.Time
+			for prev := s.Prev(end); !prev.Before(start); prev = s.Prev(prev) {
+				backward = append([]time.Time{prev}, backward...)
+			}
+			if len(forward) != len(backward) {
+				t.Errorf("%q in %v: got %d activations backward, want %d", expr, r.loc, len(backward), len(forward))
+				continue
+			}
+			for i := range forward {
+				if !forward[i].Equal(backward[i]) {
+					t.Errorf("%q in %v: activation #%d = %v, want %v", expr, r.loc, i, backward[i], forward[i])
+					break
+				}
+			}
+		}
+	}
+}
+
+func TestCronRange_WallClockDST(t *testing.T) {
+	locationBerlin, _ := time.LoadLocation("Europe/Berlin")
+	berlin := func(s string) time.Time {
+		return parseTime(locationBerlin, s)
+	}
+	utc := func(s string) time.Time {
+		return parseTime(locationUTC, s)
+	}
+	tests := []struct {
+		name  string
+		expr  string
+		from  time.Time
+		count int
+		want  []TimeRange
+	}{
+		{"Absolute day across the gap", "DR=1440; TZ=Europe/Berlin; 0 0 * * *", berlin("2020-03-28 12:00:00"), 2, []TimeRange{
+			{berlin("2020-03-29 00:00:00"), berlin("2020-03-30 01:00:00")},
+			{berlin("2020-03-30 00:00:00"), berlin("2020-03-31 00:00:00")},
+		}},
+		{"Wall-clock day across the gap", "DR=1440; TZ=Europe/Berlin; DST=wall; 0 0 * * *", berlin("2020-03-28 12:00:00"), 2, []TimeRange{
+			{berlin("2020-03-29 00:00:00"), berlin("2020-03-30 00:00:00")},
+			{berlin("2020-03-30 00:00:00"), berlin("2020-03-31 00:00:00")},
+		}},
+		{"Absolute day across the repeated hour", "DR=1440; TZ=Europe/Berlin; 0 0 * * *", berlin("2020-10-24 12:00:00"), 1, []TimeRange{
+			{berlin("2020-10-25 00:00:00"), berlin("2020-10-25 23:00:00")},
+		}},
+		{"Wall-clock day across the repeated hour", "DR=1440; TZ=Europe/Berlin; DST=wall; 0 0 * * *", berlin("2020-10-24 12:00:00"), 1, []TimeRange{
+			{berlin("2020-10-25 00:00:00"), berlin("2020-10-26 00:00:00")},
+		}},
+		{"Absolute start in the gap", "DR=60; TZ=Europe/Berlin; 30 2 * * *", berlin("2020-03-28 12:00:00"), 1, []TimeRange{
+			{berlin("2020-03-30 02:30:00"), berlin("2020-03-30 03:30:00")},
+		}},
+		{"Wall-clock start in the gap", "DR=60; TZ=Europe/Berlin; DST=wall; 30 2 * * *", berlin("2020-03-28 12:00:00"), 2, []TimeRange{
+			{utc("2020-03-29 01:30:00"), utc("2020-03-29 02:30:00")},
+			{berlin("2020-03-30 02:30:00"), berlin("2020-03-30 03:30:00")},
+		}},
+		{"Absolute start in the repeated hour", "DR=60; TZ=Europe/Berlin; 30 2 * * *", berlin("2020-10-24 12:00:00"), 2, []TimeRange{
+			{utc("2020-10-25 00:30:00"), utc("2020-10-25 01:30:00")},
+			{utc("2020-10-25 01:30:00"), utc("2020-10-25 02:30:00")},
+		}},
+		{"Wall-clock start in the repeated hour", "DR=60; TZ=Europe/Berlin; DST=wall; 30 2 * * *", berlin("2020-10-24 12:00:00"), 2, []TimeRange{
+			{utc("2020-10-25 00:30:00"), utc("2020-10-25 02:30:00")},
+			{berlin("2020-10-26 02:30:00"), berlin("2020-10-26 03:30:00")},
+		}},
+		{"Wall-clock end in the repeated hour", "DR=30; TZ=Europe/Berlin; DST=wall; 0 2 * * *", berlin("2020-10-24 12:00:00"), 1, []TimeRange{
+			{utc("2020-10-25 00:00:00"), utc("2020-10-25 00:30:00")},
+		}},
+		{"Wall-clock end expression in the gap", "END=0 3 * * *; TZ=Europe/Berlin; DST=wall; 30 2 * * *", berlin("2025-03-29 12:00:00"), 2, []TimeRange{
+			{berlin("2025-03-30 03:30:00"), berlin("2025-03-30 04:00:00")},
+			{berlin("2025-03-31 02:30:00"), berlin("2025-03-31 03:00:00")},
+		}},
+		{"Wall-clock time window in the gap", "TZ=Europe/Berlin; DST=wall; 02:30-03:00", berlin("2025-03-29 12:00:00"), 1, []TimeRange{
+			{berlin("2025-03-30 03:30:00"), berlin("2025-03-30 04:00:00")},
+		}},
+		{"Wall-clock end expression after the gap", "END=0 3 * * *; TZ=Europe/Berlin; DST=wall; 30 3 * * *", berlin("2025-03-29 12:00:00"), 1, []TimeRange{
+			{berlin("2025-03-30 03:30:00"), berlin("2025-03-31 03:00:00")},
+		}},
+		{"Wall-clock end expression across the gap", "END=0 6 * * *; TZ=Europe/Berlin; DST=wall; 30 2 * * *", berlin("2025-03-29 12:00:00"), 1, []TimeRange{
+			{berlin("2025-03-30 03:30:00"), berlin("2025-03-30 06:00:00")},
+		}},
+		{"Wall-clock month across the gap", "DR=P1M; TZ=Europe/Berlin; DST=wall; 0 0 1 * *", berlin("2020-02-15 00:00:00"), 1, []TimeRange{
+			{berlin("2020-03-01 00:00:00"), berlin("2020-04-01 00:00:00")},
+		}},
+		{"Wall-clock with offset", "DR=1440; TZ=Europe/Berlin; DST=wall; OFF=1h; 0 0 * * *", berlin("2020-03-28 12:00:00"), 1, []TimeRange{
+			{berlin("2020-03-29 01:00:00"), berlin("2020-03-30 01:00:00")},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cr := crMustParse(tt.expr)
+			got := cr.NextOccurrences(tt.from, tt.count)
+			if !isTimeRangeSliceEqual(got, tt.want) {
+				t.Errorf("NextOccurrences() got = %v, want %v", got, tt.want)
+			}
+
+			// the same time ranges are found backward in reverse order
+			last := tt.want[len(tt.want)-1]
+			prev := cr.PreviousOccurrences(last.Start.Add(time.Nanosecond), len(tt.want))
+			for i, j := 0, len(prev)-1; i < j; i, j = i+1, j-1 {
+				prev[i], prev[j] = prev[j], prev[i]
+			}
+			if !isTimeRangeSliceEqual(prev, tt.want) {
+				t.Errorf("PreviousOccurrences() got = %v, want %v", prev, tt.want)
+			}
+
+			// each time range contains the moment right before its end
+			for _, tr := range tt.want {
+				if at := tr.End.Add(-time.Second); !cr.IsWithin(at) {
+					t.Errorf("IsWithin(%v) got = false, want true", at)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkWallSchedule_Next(b *testing.B) {
+	locationBerlin, _ := time.LoadLocation("Europe/Berlin")
+	inner, _ := cron.ParseStandard("30 2 * * *")
+	s := wallSchedule{inner: inner, loc: locationBerlin}
+	at := parseTime(locationBerlin, "2020-03-29 00:00:00")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = s.Next(at)
+	}
+}
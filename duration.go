@@ -0,0 +1,363 @@
+package cronrange
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	errEmptyDuration   = errors.New("duration is empty")
+	errInvalidDuration = errors.New("duration is invalid")
+	errDurationTooLong = errors.New("duration is too long")
+
+	// durationUnits lists the exact units accepted in DR= part, longer suffixes come first to be matched greedily.
+	durationUnits = []struct {
+		suffix string
+		length time.Duration
+	}{
+		{"ms", time.Millisecond},
+		{"us", time.Microsecond},
+		{"µs", time.Microsecond},
+		{"ns", time.Nanosecond},
+		{"d", 24 * time.Hour},
+		{"h", time.Hour},
+		{"m", time.Minute},
+		{"s", time.Second},
+	}
+
+	// periodUnits lists the nominal calendar units accepted in DR= part, and the period of one unit.
+	periodUnits = []struct {
+		suffix string
+		unit   period
+	}{
+		{"mo", period{months: 1}},
+		{"y", period{years: 1}},
+		{"w", period{days: 7}},
+	}
+)
+
+// parseDuration parses the value of DR= part, which can be either a plain number of minutes like "90",
+// or a sequence of decimal numbers with unit suffixes like "90s", "1h30m", "2d" or "1mo".
+// Valid exact units are "d" (24 hours), "h", "m", "s", "ms", "us" (or "µs") and "ns",
+// and valid nominal units are "y", "mo" and "w" (7 calendar days), which only take integers.
+func parseDuration(s string) (p period, d time.Duration, err error) {
+	if s == "" {
+		err = errEmptyDuration
+		return
+	}
+
+	// Plain number stands for minutes
+	if durMin, e := strconv.ParseUint(s, 10, 64); e == nil {
+		if durMin > uint64(math.MaxInt64/int64(time.Minute)) {
+			err = fmt.Errorf("duration overflows: %q", s)
+			return
+		}
+		d = time.Minute * time.Duration(durMin)
+		return
+	}
+
+PL:
+	for rest := s; rest != ""; {
+		// Leading number
+		idx := strings.IndexFunc(rest, func(r rune) bool {
+			return !(r >= '0' && r <= '9' || r == '.')
+		})
+		if idx <= 0 {
+			err = fmt.Errorf("%w: %q", errInvalidDuration, s)
+			return
+		}
+		numStr := rest[:idx]
+		rest = rest[idx:]
+
+		// Nominal unit suffix
+		for _, u := range periodUnits {
+			if strings.HasPrefix(rest, u.suffix) {
+				var num int64
+				if num, err = strconv.ParseInt(numStr, 10, 32); err != nil {
+					err = fmt.Errorf("%w: %q", errInvalidDuration, s)
+					return
+				}
+				p.years += u.unit.years * int(num)
+				p.months += u.unit.months * int(num)
+				p.days += u.unit.days * int(num)
+				rest = rest[len(u.suffix):]
+				continue PL
+			}
+		}
+
+		// Exact unit suffix
+		var unit time.Duration
+		for _, u := range durationUnits {
+			if strings.HasPrefix(rest, u.suffix) {
+				unit = u.length
+				rest = rest[len(u.suffix):]
+				break
+			}
+		}
+		var num float64
+		if num, err = strconv.ParseFloat(numStr, 64); err != nil || unit == 0 {
+			err = fmt.Errorf("%w: %q", errInvalidDuration, s)
+			return
+		}
+
+		part := num * float64(unit)
+		if part > float64(math.MaxInt64-d) {
+			err = fmt.Errorf("duration overflows: %q", s)
+			return
+		}
+		d += time.Duration(math.Round(part))
+	}
+	return
+}
+
+// formatDuration returns the shortest lossless representation of the duration for DR= part,
+// it's a plain number of minutes for exact whole minutes, or a sequence of unit-suffixed numbers otherwise.
+// Nominal days are not representable in this form unless they're whole weeks, check it with canFormatDuration() first.
+func formatDuration(p period, d time.Duration) string {
+	if p.isZero() && d%time.Minute == 0 {
+		return strconv.FormatUint(uint64(d/time.Minute), 10)
+	}
+
+	sb := strings.Builder{}
+	for _, c := range []struct {
+		num    int
+		suffix string
+	}{
+		{p.years, "y"},
+		{p.months, "mo"},
+		{p.days / 7, "w"},
+	} {
+		if c.num > 0 {
+			sb.WriteString(strconv.Itoa(c.num))
+			sb.WriteString(c.suffix)
+		}
+	}
+	for _, u := range []struct {
+		suffix string
+		length time.Duration
+	}{
+		{"d", 24 * time.Hour},
+		{"h", time.Hour},
+		{"m", time.Minute},
+		{"s", time.Second},
+		{"ms", time.Millisecond},
+		{"us", time.Microsecond},
+		{"ns", time.Nanosecond},
+	} {
+		if n := d / u.length; n > 0 {
+			sb.WriteString(strconv.FormatInt(int64(n), 10))
+			sb.WriteString(u.suffix)
+			d -= n * u.length
+		}
+	}
+	return sb.String()
+}
+
+// canFormatDuration checks if the duration is representable by formatDuration().
+func canFormatDuration(p period) bool {
+	return p.days%7 == 0
+}
+
+// period is the nominal part of a duration, measured on the calendar in the time zone of the CronRange,
+// so its actual length varies with the starting time, e.g. a month can be 28 to 31 days, and a day can be 23 to 25 hours across DST changes.
+type period struct {
+	years, months, days int
+}
+
+func (p period) isZero() bool {
+	return p.years == 0 && p.months == 0 && p.days == 0
+}
+
+// maxLength returns the upper bound of the actual length of the period.
+func (p period) maxLength() time.Duration {
+	if p.isZero() {
+		return 0
+	}
+	return time.Duration(p.years*366+p.months*31+p.days)*25*time.Hour + time.Hour
+}
+
+// fits checks if the upper bound of the length of the period along with the exact duration and a DST shift doesn't overflow time.Duration.
+func (p period) fits(d time.Duration) bool {
+	if p.years < 0 || p.months < 0 || p.days < 0 || d < 0 {
+		// the negative ones are rejected elsewhere
+		return true
+	}
+	days := int64(p.years)*366 + int64(p.months)*31 + int64(p.days)
+	return days <= (math.MaxInt64-int64(d)-int64(time.Hour+maxDSTShift))/int64(25*time.Hour)
+}
+
+// addTo returns the time after the period elapses from the given time, with wall clock in the location.
+// Years and months are added first, and the day of month is clamped to the end of month if it overflows, e.g. Jan 31 + 1 month = Feb 28 (or 29),
+// and then days are added.
+func (p period) addTo(t time.Time, loc *time.Location) time.Time {
+	if p.isZero() {
+		return t
+	}
+	orig := t.Location()
+	if loc != nil {
+		t = t.In(loc)
+	}
+
+	year, month, day := t.Date()
+	hour, min, sec := t.Clock()
+	if p.years != 0 || p.months != 0 {
+		firstDay := time.Date(year+p.years, month+time.Month(p.months), 1, 0, 0, 0, 0, time.UTC)
+		year, month = firstDay.Year(), firstDay.Month()
+		if lastDay := firstDay.AddDate(0, 1, -1).Day(); day > lastDay {
+			day = lastDay
+		}
+	}
+	return time.Date(year, month, day+p.days, hour, min, sec, t.Nanosecond(), t.Location()).In(orig)
+}
+
+// parseISODuration parses a duration in ISO 8601 format like "PT2H30M", "P1DT4H" or "P1M", into nominal period and exact duration.
+//
+// Years, months, weeks and days are nominal and resolved on the calendar of the time zone, e.g. "P1D" lasts 23 hours if DST starts on the day,
+// while hours, minutes and seconds are exact, e.g. "PT24H" always lasts 24 hours. Only the seconds can have a fractional part.
+func parseISODuration(s string) (p period, d time.Duration, err error) {
+	invalid := fmt.Errorf("%w: %q", errInvalidDuration, s)
+	if len(s) < 3 || s[0] != 'P' {
+		err = invalid
+		return
+	}
+
+	var (
+		rest     = s[1:]
+		inTime   bool
+		lastUnit = -1
+		// designators in the order they must appear
+		dateUnits = "YMWD"
+		timeUnits = "HMS"
+	)
+	for rest != "" {
+		if rest[0] == 'T' {
+			if inTime || len(rest) == 1 {
+				err = invalid
+				return
+			}
+			inTime, lastUnit, rest = true, -1, rest[1:]
+			continue
+		}
+
+		idx := strings.IndexFunc(rest, func(r rune) bool {
+			return !(r >= '0' && r <= '9' || r == '.')
+		})
+		if idx <= 0 {
+			err = invalid
+			return
+		}
+		numStr, unit := rest[:idx], rest[idx]
+		rest = rest[idx+1:]
+
+		units := dateUnits
+		if inTime {
+			units = timeUnits
+		}
+		pos := strings.IndexByte(units, unit)
+		if pos <= lastUnit {
+			err = invalid
+			return
+		}
+		lastUnit = pos
+
+		if inTime && unit == 'S' {
+			var sec float64
+			if sec, err = strconv.ParseFloat(numStr, 64); err != nil || sec*float64(time.Second) > float64(math.MaxInt64-d) {
+				err = invalid
+				return
+			}
+			d += time.Duration(math.Round(sec * float64(time.Second)))
+			continue
+		}
+
+		var num int64
+		if num, err = strconv.ParseInt(numStr, 10, 32); err != nil {
+			err = invalid
+			return
+		}
+		switch {
+		case !inTime && unit == 'Y':
+			p.years = int(num)
+		case !inTime && unit == 'M':
+			p.months = int(num)
+		case !inTime && unit == 'W':
+			p.days += int(num) * 7
+		case !inTime && unit == 'D':
+			p.days += int(num)
+		default:
+			length := time.Hour
+			if unit == 'M' {
+				length = time.Minute
+			}
+			if num > int64((math.MaxInt64-d)/length) {
+				err = invalid
+				return
+			}
+			d += time.Duration(num) * length
+		}
+	}
+	return
+}
+
+// formatISODuration returns the duration in ISO 8601 format, e.g. "P1Y2M3DT4H5M6.5S".
+func formatISODuration(p period, d time.Duration) string {
+	sb := strings.Builder{}
+	sb.WriteString("P")
+	for _, c := range []struct {
+		num  int
+		unit string
+	}{
+		{p.years, "Y"},
+		{p.months, "M"},
+		{p.days, "D"},
+	} {
+		if c.num != 0 {
+			sb.WriteString(strconv.Itoa(c.num))
+			sb.WriteString(c.unit)
+		}
+	}
+
+	if d > 0 || p.isZero() {
+		sb.WriteString("T")
+		if h := d / time.Hour; h > 0 {
+			sb.WriteString(strconv.FormatInt(int64(h), 10))
+			sb.WriteString("H")
+			d -= h * time.Hour
+		}
+		if m := d / time.Minute; m > 0 {
+			sb.WriteString(strconv.FormatInt(int64(m), 10))
+			sb.WriteString("M")
+			d -= m * time.Minute
+		}
+		if d > 0 || sb.Len() == 2 {
+			sb.WriteString(strconv.FormatFloat(d.Seconds(), 'f', -1, 64))
+			sb.WriteString("S")
+		}
+	}
+	return sb.String()
+}
+
+// parseDurationExpr parses the value of DR= part in either ISO 8601 format or the plain format accepted by parseDuration.
+func parseDurationExpr(s string) (p period, d time.Duration, err error) {
+	if strings.HasPrefix(s, "P") {
+		p, d, err = parseISODuration(s)
+	} else {
+		p, d, err = parseDuration(s)
+	}
+	if err == nil && !p.fits(d) {
+		err = fmt.Errorf("%w: %q", errDurationTooLong, s)
+	}
+	return
+}
+
+// formatDurationExpr returns the value of DR= part, durations with nominal days other than whole weeks are always formatted in ISO 8601 format.
+func formatDurationExpr(p period, d time.Duration, iso bool) string {
+	if iso || !canFormatDuration(p) {
+		return formatISODuration(p, d)
+	}
+	return formatDuration(p, d)
+}
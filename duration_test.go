@@ -0,0 +1,209 @@
+package cronrange
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		wantP   period
+		wantD   time.Duration
+		wantErr bool
+	}{
+		{"Empty string", emptyString, period{}, 0, true},
+		{"Negative minutes", "-5", period{}, 0, true},
+		{"Missing number", "h", period{}, 0, true},
+		{"Missing unit", "1h30", period{}, 0, true},
+		{"Unknown unit", "5x", period{}, 0, true},
+		{"Whitespace inside", "1h 30m", period{}, 0, true},
+		{"Broken number", "1.2.3s", period{}, 0, true},
+		{"Fractional month", "1.5mo", period{}, 0, true},
+		{"Overflow minutes", "99999999999999", period{}, 0, true},
+		{"Overflow days", "999999999d", period{}, 0, true},
+		{"Zero minute", "0", period{}, 0, false},
+		{"Plain minutes", "1440", period{}, 24 * time.Hour, false},
+		{"Seconds", "90s", period{}, 90 * time.Second, false},
+		{"Hours and minutes", "1h30m", period{}, 90 * time.Minute, false},
+		{"Days", "2d", period{}, 48 * time.Hour, false},
+		{"Fractional hours", "1.5h", period{}, 90 * time.Minute, false},
+		{"Sub-second units", "1s500ms250us", period{}, time.Second + 500*time.Millisecond + 250*time.Microsecond, false},
+		{"Micro sign", "3µs7ns", period{}, 3*time.Microsecond + 7*time.Nanosecond, false},
+		{"All exact units", "1d2h3m4s", period{}, 26*time.Hour + 3*time.Minute + 4*time.Second, false},
+		{"Month", "1mo", period{months: 1}, 0, false},
+		{"Year", "1y", period{years: 1}, 0, false},
+		{"Weeks", "2w", period{days: 14}, 0, false},
+		{"Month and milliseconds", "1mo5ms", period{months: 1}, 5 * time.Millisecond, false},
+		{"All units", "1y2mo3w4d5h6m7s", period{1, 2, 21}, 101*time.Hour + 6*time.Minute + 7*time.Second, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotP, gotD, err := parseDuration(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseDuration() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && (gotP != tt.wantP || gotD != tt.wantD) {
+				t.Errorf("parseDuration() gotP = %v, gotD = %v, want %v, %v", gotP, gotD, tt.wantP, tt.wantD)
+			}
+		})
+	}
+}
+
+func BenchmarkParseDuration(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _, _ = parseDuration("1d2h3m4s")
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		name string
+		p    period
+		d    time.Duration
+		want string
+	}{
+		{"One minute", period{}, time.Minute, "1"},
+		{"Whole day", period{}, 24 * time.Hour, "1440"},
+		{"Hour and half", period{}, 90 * time.Minute, "90"},
+		{"Seconds", period{}, 90 * time.Second, "1m30s"},
+		{"Half second", period{}, 500 * time.Millisecond, "500ms"},
+		{"Days with seconds", period{}, 49*time.Hour + time.Second, "2d1h1s"},
+		{"Nanoseconds", period{}, time.Second + time.Nanosecond, "1s1ns"},
+		{"Month", period{months: 1}, 0, "1mo"},
+		{"Year and weeks", period{years: 1, days: 14}, 0, "1y2w"},
+		{"Month and minutes", period{months: 1}, 90 * time.Minute, "1mo1h30m"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := formatDuration(tt.p, tt.d)
+			if got != tt.want {
+				t.Errorf("formatDuration() = %q, want %q", got, tt.want)
+				return
+			}
+			if p, d, err := parseDuration(got); err != nil || p != tt.p || d != tt.d {
+				t.Errorf("parseDuration(formatDuration()) = %v, %v, %v, want %v, %v", p, d, err, tt.p, tt.d)
+			}
+		})
+	}
+}
+
+func BenchmarkFormatDuration(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = formatDuration(period{}, 26*time.Hour+3*time.Minute+4*time.Second)
+	}
+}
+
+func TestParseISODuration(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		wantP   period
+		wantD   time.Duration
+		wantErr bool
+	}{
+		{"Empty string", emptyString, period{}, 0, true},
+		{"Only designator", "P", period{}, 0, true},
+		{"Only time designator", "PT", period{}, 0, true},
+		{"Trailing time designator", "P1DT", period{}, 0, true},
+		{"Missing designator", "1D", period{}, 0, true},
+		{"Missing number", "PDT1H", period{}, 0, true},
+		{"Missing unit", "PT30", period{}, 0, true},
+		{"Wrong order", "PT30M1H", period{}, 0, true},
+		{"Duplicate unit", "P1D2D", period{}, 0, true},
+		{"Time unit in date", "P1H", period{}, 0, true},
+		{"Date unit in time", "PT1D", period{}, 0, true},
+		{"Fractional hours", "PT1.5H", period{}, 0, true},
+		{"Negative value", "P-1D", period{}, 0, true},
+		{"Lower case", "pt1h", period{}, 0, true},
+		{"Hours and minutes", "PT2H30M", period{}, 150 * time.Minute, false},
+		{"Day and hours", "P1DT4H", period{days: 1}, 4 * time.Hour, false},
+		{"One month", "P1M", period{months: 1}, 0, false},
+		{"One minute", "PT1M", period{}, time.Minute, false},
+		{"Weeks", "P2W", period{days: 14}, 0, false},
+		{"Fractional seconds", "PT1.5S", period{}, 1500 * time.Millisecond, false},
+		{"All components", "P1Y2M3W4DT5H6M7S", period{1, 2, 25}, 5*time.Hour + 6*time.Minute + 7*time.Second, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotP, gotD, err := parseISODuration(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseISODuration() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && (gotP != tt.wantP || gotD != tt.wantD) {
+				t.Errorf("parseISODuration() gotP = %v, gotD = %v, want %v, %v", gotP, gotD, tt.wantP, tt.wantD)
+			}
+		})
+	}
+}
+
+func BenchmarkParseISODuration(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _, _ = parseISODuration("P1Y2M3DT4H5M6S")
+	}
+}
+
+func TestFormatISODuration(t *testing.T) {
+	tests := []struct {
+		name string
+		p    period
+		d    time.Duration
+		want string
+	}{
+		{"Zero", period{}, 0, "PT0S"},
+		{"Hours and minutes", period{}, 150 * time.Minute, "PT2H30M"},
+		{"Fractional seconds", period{}, 1500 * time.Millisecond, "PT1.5S"},
+		{"Day and hours", period{days: 1}, 4 * time.Hour, "P1DT4H"},
+		{"Month", period{months: 1}, 0, "P1M"},
+		{"All components", period{1, 2, 3}, 4*time.Hour + 5*time.Minute + 6*time.Second, "P1Y2M3DT4H5M6S"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := formatISODuration(tt.p, tt.d)
+			if got != tt.want {
+				t.Errorf("formatISODuration() = %q, want %q", got, tt.want)
+				return
+			}
+			if p, d, err := parseISODuration(got); err != nil || p != tt.p || d != tt.d {
+				t.Errorf("parseISODuration(formatISODuration()) = %v, %v, %v, want %v, %v", p, d, err, tt.p, tt.d)
+			}
+		})
+	}
+}
+
+func TestPeriod_AddTo(t *testing.T) {
+	locationNewYork, _ := time.LoadLocation(timeZoneNewYork)
+	tests := []struct {
+		name  string
+		p     period
+		start time.Time
+		loc   *time.Location
+		want  time.Time
+	}{
+		{"Zero period", period{}, firstSec2019Bangkok, nil, firstSec2019Bangkok},
+		{"One month in January", period{months: 1}, firstSec2019Bangkok, locationBangkok, parseTime(locationBangkok, "2019-02-01 00:00:00")},
+		{"One month in February", period{months: 1}, parseTime(locationBangkok, "2019-02-01 00:00:00"), locationBangkok, parseTime(locationBangkok, "2019-03-01 00:00:00")},
+		{"One month from the end of January", period{months: 1}, parseTime(locationUTC, "2019-01-31 08:00:00"), nil, parseTime(locationUTC, "2019-02-28 08:00:00")},
+		{"One month from the end of January in leap year", period{months: 1}, parseTime(locationUTC, "2020-01-31 08:00:00"), nil, parseTime(locationUTC, "2020-02-29 08:00:00")},
+		{"One year from leap day", period{years: 1}, parseTime(locationUTC, "2020-02-29 08:00:00"), nil, parseTime(locationUTC, "2021-02-28 08:00:00")},
+		{"Month over year end", period{months: 2}, parseTime(locationUTC, "2019-12-15 08:00:00"), nil, parseTime(locationUTC, "2020-02-15 08:00:00")},
+		{"Month and days", period{months: 1, days: 3}, parseTime(locationUTC, "2019-01-31 08:00:00"), nil, parseTime(locationUTC, "2019-03-03 08:00:00")},
+		{"One day across DST start", period{days: 1}, parseTime(locationNewYork, "2019-03-10 00:00:00"), locationNewYork, parseTime(locationUTC, "2019-03-11 04:00:00")},
+		{"One day across DST end", period{days: 1}, parseTime(locationNewYork, "2019-11-03 00:00:00"), locationNewYork, parseTime(locationUTC, "2019-11-04 05:00:00")},
+		{"One day in UTC view", period{days: 1}, parseTime(locationUTC, "2019-03-10 05:00:00"), locationNewYork, parseTime(locationUTC, "2019-03-11 04:00:00")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.p.addTo(tt.start, tt.loc)
+			if !got.Equal(tt.want) {
+				t.Errorf("addTo() = %v, want %v", got, tt.want)
+			}
+			if got.Location() != tt.start.Location() {
+				t.Errorf("addTo() location = %v, want %v", got.Location(), tt.start.Location())
+			}
+		})
+	}
+}
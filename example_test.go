@@ -54,6 +54,106 @@ func ExampleCronRange_NextOccurrences() {
 	// [2019-11-14T15:00:00-10:00,2019-11-14T17:00:00-10:00]
 }
 
+// This example lists last 3 daily happy hours of Lava Lava Beach Club before 2019.11.09.
+func ExampleCronRange_PreviousOccurrences() {
+	cr, err := cronrange.New("0 15 * * *", "Pacific/Honolulu", 120)
+	if err != nil {
+		fmt.Println("fail to create:", err)
+		return
+	}
+
+	loc, _ := time.LoadLocation("Pacific/Honolulu")
+	currTime := time.Date(2019, 11, 9, 16, 55, 0, 0, loc)
+	for _, happyHour := range cr.PreviousOccurrences(currTime, 3) {
+		fmt.Println(happyHour)
+	}
+
+	// Output:
+	// [2019-11-09T15:00:00-10:00,2019-11-09T17:00:00-10:00]
+	// [2019-11-08T15:00:00-10:00,2019-11-08T17:00:00-10:00]
+	// [2019-11-07T15:00:00-10:00,2019-11-07T17:00:00-10:00]
+}
+
+// This example lists the daily happy hours of Lava Lava Beach Club during a visit from 2019.11.09 16:00 to 2019.11.11 16:00.
+func ExampleCronRange_OccurrencesBetween() {
+	cr, err := cronrange.New("0 15 * * *", "Pacific/Honolulu", 120)
+	if err != nil {
+		fmt.Println("fail to create:", err)
+		return
+	}
+
+	loc, _ := time.LoadLocation("Pacific/Honolulu")
+	arrival, departure := time.Date(2019, 11, 9, 16, 0, 0, 0, loc), time.Date(2019, 11, 11, 16, 0, 0, 0, loc)
+	for _, happyHour := range cr.OccurrencesBetween(arrival, departure) {
+		fmt.Println(happyHour)
+	}
+
+	// Output:
+	// [2019-11-09T15:00:00-10:00,2019-11-09T17:00:00-10:00]
+	// [2019-11-10T15:00:00-10:00,2019-11-10T17:00:00-10:00]
+	// [2019-11-11T15:00:00-10:00,2019-11-11T17:00:00-10:00]
+}
+
+// This example finds the first weekend happy hour of Lava Lava Beach Club after 2019.11.09, and the one before it.
+func ExampleCronRange_Iterator() {
+	cr, err := cronrange.New("0 15 * * *", "Pacific/Honolulu", 120)
+	if err != nil {
+		fmt.Println("fail to create:", err)
+		return
+	}
+
+	loc, _ := time.LoadLocation("Pacific/Honolulu")
+	it := cr.Iterator(time.Date(2019, 11, 9, 16, 55, 0, 0, loc))
+	for happyHour, ok := it.Next(); ok; happyHour, ok = it.Next() {
+		if wd := happyHour.Start.Weekday(); wd == time.Saturday || wd == time.Sunday {
+			fmt.Println(happyHour)
+			break
+		}
+	}
+	it.Prev()
+	if happyHour, ok := it.Prev(); ok {
+		fmt.Println(happyHour)
+	}
+
+	// Output:
+	// [2019-11-10T15:00:00-10:00,2019-11-10T17:00:00-10:00]
+	// [2019-11-09T15:00:00-10:00,2019-11-09T17:00:00-10:00]
+}
+
+// This example tells when the happy hour of Lava Lava Beach Club closes at 2019.11.09 16:37.
+func ExampleCronRange_Remaining() {
+	cr, err := cronrange.New("0 15 * * *", "Pacific/Honolulu", 120)
+	if err != nil {
+		fmt.Println("fail to create:", err)
+		return
+	}
+
+	loc, _ := time.LoadLocation("Pacific/Honolulu")
+	if remaining, within := cr.Remaining(time.Date(2019, 11, 9, 16, 37, 0, 0, loc)); within {
+		fmt.Println("closes in", remaining)
+	}
+
+	// Output: closes in 23m0s
+}
+
+// This example shows the daily time ranges ending at midnight on the wall clock across a DST transition.
+func ExampleCronRange_WithDSTMode() {
+	cr, err := cronrange.ParseString("DR=1440; TZ=Europe/Berlin; DST=wall; 0 0 * * *")
+	if err != nil {
+		fmt.Println("fail to parse:", err)
+		return
+	}
+
+	loc, _ := time.LoadLocation("Europe/Berlin")
+	for _, tr := range cr.NextOccurrences(time.Date(2020, 3, 28, 12, 0, 0, 0, loc), 2) {
+		fmt.Println(tr.Start.Format(time.RFC3339), "-", tr.End.Format(time.RFC3339))
+	}
+
+	// Output:
+	// 2020-03-29T00:00:00+01:00 - 2020-03-30T00:00:00+02:00
+	// 2020-03-30T00:00:00+02:00 - 2020-03-31T00:00:00+02:00
+}
+
 // This example shows greeting according to your local box time.
 func ExampleCronRange_IsWithin() {
 	crGreetings := make(map[*cronrange.CronRange]string)
@@ -84,6 +184,26 @@ func ExampleCronRange_IsWithin() {
 	}
 }
 
+// This example lists next 3 office hours except the lunch break and weekends, with a set of CronRange.
+func ExampleParseSetString() {
+	set, err := cronrange.ParseSetString("[DR=480; TZ=Asia/Tokyo; 0 9 * * *] - ([DR=60; TZ=Asia/Tokyo; 0 12 * * *] | [DR=2880; TZ=Asia/Tokyo; 0 0 * * 6])")
+	if err != nil {
+		fmt.Println("fail to create:", err)
+		return
+	}
+
+	loc, _ := time.LoadLocation("Asia/Tokyo")
+	currTime := time.Date(2019, 11, 8, 10, 0, 0, 0, loc)
+	for _, officeHour := range set.NextOccurrences(currTime, 3) {
+		fmt.Println(officeHour)
+	}
+
+	// Output:
+	// [2019-11-08T13:00:00+09:00,2019-11-08T17:00:00+09:00]
+	// [2019-11-11T09:00:00+09:00,2019-11-11T12:00:00+09:00]
+	// [2019-11-11T13:00:00+09:00,2019-11-11T17:00:00+09:00]
+}
+
 // This example demonstrates serializing a struct containing CronRange to JSON.
 func ExampleCronRange_MarshalJSON() {
 	cr, err := cronrange.ParseString("DR=240;TZ=America/New_York;0 8 1 1 *")
@@ -2,21 +2,153 @@ package cronrange
 
 import (
 	"time"
+
+	"github.com/robfig/cron/v3"
 )
 
 func (cr *CronRange) checkPrecondition() {
 	switch {
 	case cr == nil:
 		panic("CronRange is nil")
-	case cr.duration <= 0:
+	case cr.endSchedule == nil && (cr.duration < 0 || (cr.duration == 0 && cr.period.isZero())):
 		panic("duration of CronRange is not positive")
 	case cr.schedule == nil:
 		panic("schedule of CronRange is nil")
 	}
 }
 
+// maxEndSearch is the upper bound of the length of time ranges ending by the end expression,
+// since the schedules don't look for the next activation beyond five years.
+const maxEndSearch = 5 * 366 * 24 * time.Hour
+
+// endOf returns the ending time of the time range starting at the given time.
+// For the end expression, it's the first activation after the start, or the zero time if it's not found.
+func (cr *CronRange) endOf(start time.Time) time.Time {
+	if cr.endSchedule != nil {
+		return cr.endSchedule.Next(start)
+	}
+	if cr.dstMode == WallClockDST {
+		// apply the duration on the wall clock, and resolve it in the time zone
+		loc := cr.location
+		if loc == nil {
+			loc = start.Location()
+		}
+		wall := cr.period.addTo(wallClockOf(start, loc), nil).Add(cr.duration)
+		return resolveWallClock(wall, loc).In(start.Location())
+	}
+	return cr.period.addTo(start, cr.location).Add(cr.duration)
+}
+
+// maxLength returns the upper bound of the length of time ranges.
+func (cr *CronRange) maxLength() time.Duration {
+	if cr.endSchedule != nil {
+		return maxEndSearch
+	}
+	if cr.dstMode == WallClockDST {
+		return cr.period.maxLength() + cr.duration + maxDSTShift
+	}
+	return cr.period.maxLength() + cr.duration
+}
+
+// lastActivation returns the latest activation time of the schedule within (t-limit, t], or false if there's none.
+func lastActivation(s cron.Schedule, t time.Time, limit time.Duration) (last time.Time, found bool) {
+	if hasPrev(s) {
+		if last = prevActivation(s, t.Add(time.Nanosecond)); !last.IsZero() && last.After(t.Add(-limit)) {
+			return last, true
+		}
+		return time.Time{}, false
+	}
+	return probeLastActivation(s, t, limit)
+}
+
+// probeLastActivation finds the latest activation time within (t-limit, t] with Next() only, by narrowing down the window and walking forward.
+func probeLastActivation(s cron.Schedule, t time.Time, limit time.Duration) (last time.Time, found bool) {
+	from := t.Add(-limit)
+	if last = s.Next(from); last.Before(from) || last.After(t) {
+		return time.Time{}, false
+	}
+
+	// narrow down the window while there's still an activation within it
+	for w := limit / 2; w >= time.Second; w /= 2 {
+		next := s.Next(t.Add(-w))
+		if next.Before(from) || next.After(t) {
+			break
+		}
+		last = next
+	}
+
+	// then walk forward to the latest one
+	for {
+		next := s.Next(last)
+		if !next.After(last) || next.After(t) {
+			return last, true
+		}
+		last = next
+	}
+}
+
+// exclude returns the parts of the time range not covered by any of the exceptions, which can be none or more than one.
+func (cr *CronRange) exclude(occur TimeRange) (parts []TimeRange) {
+	var excluded rangeIter
+	for _, ex := range cr.exceptionRanges() {
+		if next := mergeRanges(ex.iterate(occur.Start, occur.End)); excluded == nil {
+			excluded = next
+		} else {
+			excluded = unionRanges(excluded, next)
+		}
+	}
+
+	done := false
+	rest := subtractRanges(func() (tr TimeRange, ok bool) {
+		if !done {
+			tr, ok, done = occur, true, true
+		}
+		return
+	}, excluded)
+	for {
+		part, ok := rest()
+		if !ok {
+			return
+		}
+		parts = append(parts, part)
+	}
+}
+
+// occurrencesAt returns the time range starting at the activation time, clipped by the validity bounds and the exceptions,
+// so it can be split into none or more than one. It returns false if no ending time is found by the end expression.
+func (cr *CronRange) occurrencesAt(start time.Time) (parts []TimeRange, ok bool) {
+	occur := TimeRange{
+		Start: start,
+		End:   cr.endOf(start),
+	}
+	if occur.End.Before(occur.Start) {
+		return nil, false
+	}
+	if !cr.notBefore.IsZero() {
+		if !occur.End.After(cr.notBefore) {
+			return nil, true
+		} else if occur.Start.Before(cr.notBefore) {
+			occur.Start = cr.notBefore
+		}
+	}
+	if !cr.notAfter.IsZero() && occur.End.After(cr.notAfter) {
+		occur.End = cr.notAfter
+	}
+	if len(cr.exceptionRanges()) == 0 {
+		return []TimeRange{occur}, true
+	}
+	return cr.exclude(occur), true
+}
+
 // NextOccurrences returns the next occurrence time ranges, later than the given time.
 //
+// If the CronRange has validity bounds, the time ranges are clipped by them, and the ones out of bounds are dropped,
+// so the time range running across the not-before bound is returned with the bound as its start.
+// Likewise, the time ranges are clipped or dropped by the exceptions, and the one split by an exception counts as multiple time ranges,
+// and the ones starting more than five years after the given time are not searched if there are exceptions.
+// The ending or starting time clipped by an exception belongs to the exception if it contains that moment with its own boundary,
+// e.g. the parts [09:00,12:00] and [13:00,17:00] around a closed lunch break are open at 12:00 and 13:00, so IsWithin() returns false there.
+//
 // It panics if count is less than one, or the CronRange instance is nil or incomplete.
 func (cr *CronRange) NextOccurrences(t time.Time, count int) (occurs []TimeRange) {
 	cr.checkPrecondition()
@@ -24,40 +156,285 @@ func (cr *CronRange) NextOccurrences(t time.Time, count int) (occurs []TimeRange
 		panic("count is not positive")
 	}
 
-	for curr, i := t, 0; i < count; i++ {
+	curr := t
+	if !cr.notBefore.IsZero() && t.Before(cr.notBefore) {
+		// start from the time range running across the not-before bound if it exists
+		curr = cr.notBefore.Add(-time.Nanosecond)
+		if start, found := lastActivation(cr.schedule, cr.notBefore, cr.maxLength()+1*time.Second); found {
+			curr = start.Add(-time.Nanosecond)
+		}
+	}
+
+	for len(occurs) < count {
 		// if no occurrence is found within next five years, it returns zero time, i.e. time.Time{}
 		next := cr.schedule.Next(curr)
-		if next.Before(curr) {
+		if next.Before(curr) || (!cr.notAfter.IsZero() && next.After(cr.notAfter)) {
+			break
+		}
+		if len(cr.exceptionRanges()) > 0 && next.Sub(t) > maxSetSearch {
+			// stop searching if the exceptions drop all the time ranges
+			break
+		}
+		curr = next
+
+		parts, ok := cr.occurrencesAt(next)
+		if !ok {
+			// no ending time is found by the end expression
+			break
+		}
+		for _, part := range parts {
+			if len(occurs) == count {
+				break
+			}
+			occurs = append(occurs, part)
+		}
+	}
+
+	return
+}
+
+// PreviousOccurrences returns the previous occurrence time ranges, starting earlier than the given time, in reverse chronological order,
+// so the first one is the latest and may be still ongoing at the given time.
+//
+// The validity bounds and exceptions are applied in the same way as NextOccurrences(),
+// and the ones starting more than five years before the given time are not searched if there are exceptions.
+//
+// It panics if count is less than one, or the CronRange instance is nil or incomplete.
+func (cr *CronRange) PreviousOccurrences(t time.Time, count int) (occurs []TimeRange) {
+	cr.checkPrecondition()
+	if count <= 0 {
+		panic("count is not positive")
+	}
+
+	curr := t
+	if !cr.notAfter.IsZero() && t.After(cr.notAfter) {
+		// start from the time range starting at the not-after bound if it exists
+		curr = cr.notAfter.Add(time.Nanosecond)
+	}
+
+	for len(occurs) < count {
+		// if no occurrence is found within previous five years, it returns zero time, i.e. time.Time{}
+		prev := prevActivation(cr.schedule, curr)
+		if prev.IsZero() || !prev.Before(curr) {
+			break
+		}
+		if len(cr.exceptionRanges()) > 0 && t.Sub(prev) > maxSetSearch {
+			// stop searching if the exceptions drop all the time ranges
+			break
+		}
+		curr = prev
+
+		if !cr.notBefore.IsZero() && !cr.endOf(prev).After(cr.notBefore) {
+			// the earlier ones end before the not-before bound too
+			break
+		}
+		parts, ok := cr.occurrencesAt(prev)
+		if !ok {
+			// no ending time is found by the end expression
+			break
+		}
+		for i := len(parts) - 1; i >= 0 && len(occurs) < count; i-- {
+			// the parts split by exceptions may start after the given time
+			if parts[i].Start.Before(t) {
+				occurs = append(occurs, parts[i])
+			}
+		}
+	}
+
+	return
+}
+
+// LastOccurrence returns the latest occurrence time range starting earlier than the given time, which may be still ongoing at the given time,
+// or false if there's none within previous five years.
+//
+// It panics if the CronRange instance is nil or incomplete.
+func (cr *CronRange) LastOccurrence(t time.Time) (occur TimeRange, found bool) {
+	if occurs := cr.PreviousOccurrences(t, 1); len(occurs) > 0 {
+		occur, found = occurs[0], true
+	}
+	return
+}
+
+// OccurrencesBetween returns the occurrence time ranges overlapping with the interval from the given time to another, in chronological order,
+// i.e. the ones ending after from and starting before to, including the ones which started before from and are still running.
+// If maxCount is given, at most that many time ranges are returned. It returns nil if to is not after from.
+//
+// The validity bounds and exceptions are applied in the same way as NextOccurrences().
+//
+// It panics if maxCount is given but less than one, or the CronRange instance is nil or incomplete.
+func (cr *CronRange) OccurrencesBetween(from, to time.Time, maxCount ...int) (occurs []TimeRange) {
+	cr.checkPrecondition()
+	limit := 0
+	if len(maxCount) > 0 {
+		if limit = maxCount[0]; limit <= 0 {
+			panic("count is not positive")
+		}
+	}
+	if !from.Before(to) {
+		return
+	}
+
+	// walk back to the earliest time range still running at from, since the ending times are in the same order as the starting times
+	curr := from
+	for {
+		prev := prevActivation(cr.schedule, curr)
+		if prev.IsZero() || !cr.endOf(prev).After(from) {
 			break
 		}
-		occur := TimeRange{
-			Start: next,
-			End:   next.Add(cr.duration),
+		curr = prev
+	}
+
+	// then walk forward till to
+	for curr = curr.Add(-time.Nanosecond); ; {
+		next := cr.schedule.Next(curr)
+		if next.Before(curr) || !next.Before(to) || (!cr.notAfter.IsZero() && next.After(cr.notAfter)) {
+			break
 		}
-		occurs = append(occurs, occur)
 		curr = next
+
+		parts, ok := cr.occurrencesAt(next)
+		if !ok {
+			// no ending time is found by the end expression
+			break
+		}
+		for _, part := range parts {
+			if part.End.After(from) && part.Start.Before(to) {
+				occurs = append(occurs, part)
+				if len(occurs) == limit {
+					return
+				}
+			}
+		}
 	}
 
 	return
 }
 
 // IsWithin checks if the given time falls within any time range represented by the expression.
+// The time ranges may overlap if the duration is longer than the interval of activations, and it's within if any of them contains the given time,
+// which is the case if the latest one starting not after the given time does, since the time ranges starting later never end earlier.
+//
+// It returns false if the given time is out of the validity bounds of the CronRange, or within any of the exceptions,
+// including the moments where a time range is clipped by an exception with a closed boundary.
 //
 // It panics if the CronRange instance is nil or incomplete.
 func (cr *CronRange) IsWithin(t time.Time) (within bool) {
 	cr.checkPrecondition()
 
-	within = false
-	searchStart := t.Add(-(cr.duration + 1*time.Second))
-	rangeStart := cr.schedule.Next(searchStart)
-	rangeEnd := rangeStart.Add(cr.duration)
+	if (!cr.notBefore.IsZero() && t.Before(cr.notBefore)) || (!cr.notAfter.IsZero() && t.After(cr.notAfter)) {
+		return
+	}
+
+	for _, ex := range cr.exceptionRanges() {
+		if ex.IsWithin(t) {
+			return
+		}
+	}
+
+	// the latest time range starting before t ends the last, so it's the only one to check
+	rangeStart, found := cr.lastStart(t)
+	if !found {
+		return
+	}
+
+	// check if rangeStart <= t <= rangeEnd with the boundary
+	within = TimeRange{Start: rangeStart, End: cr.endOf(rangeStart)}.Contains(t, cr.boundary)
+	return
+}
+
+// lastStart returns the activation time of the latest time range which may contain the given time with the boundary, or false if there's none.
+func (cr *CronRange) lastStart(t time.Time) (start time.Time, found bool) {
+	if cr.boundary.openStart() {
+		// the time range starting at t doesn't contain it
+		t = t.Add(-time.Nanosecond)
+	}
+	return lastActivation(cr.schedule, t, cr.maxLength()+1*time.Second)
+}
 
-	// if no occurrence is found, it gets zero time, i.e. time.Time{}
-	if rangeStart.Before(searchStart) {
+// ContainingOccurrences returns all the occurrence time ranges containing the given time in chronological order,
+// which are more than one if they overlap, or nil if it's not within any time range like IsWithin().
+// With MergeOverlaps, they are merged into one time range from the earliest start to the latest end.
+//
+// The time ranges are clipped by the validity bounds and the exceptions.
+//
+// It panics if the CronRange instance is nil or incomplete.
+func (cr *CronRange) ContainingOccurrences(t time.Time, policy OverlapPolicy) (occurs []TimeRange) {
+	if !cr.IsWithin(t) {
 		return
 	}
 
-	// check if rangeStart <= t <= rangeEnd
-	within = (rangeStart.Before(t) && rangeEnd.After(t)) || rangeStart.Equal(t) || rangeEnd.Equal(t)
+	// walk back until the time range ends before t, since the earlier ones end earlier
+	for curr := t.Add(time.Nanosecond); ; {
+		prev := prevActivation(cr.schedule, curr)
+		if prev.IsZero() || !prev.Before(curr) || cr.endOf(prev).Before(t) {
+			break
+		}
+		curr = prev
+
+		parts, _ := cr.occurrencesAt(prev)
+		for i := len(parts) - 1; i >= 0; i-- {
+			if part := parts[i]; part.Contains(t, cr.boundary) {
+				occurs = append(occurs, part)
+			}
+		}
+	}
+
+	// reverse into chronological order
+	for i, j := 0, len(occurs)-1; i < j; i, j = i+1, j-1 {
+		occurs[i], occurs[j] = occurs[j], occurs[i]
+	}
+	if policy == MergeOverlaps && len(occurs) > 1 {
+		merged := occurs[0]
+		for _, occur := range occurs[1:] {
+			if occur.End.After(merged.End) {
+				merged.End = occur.End
+			}
+		}
+		occurs = []TimeRange{merged}
+	}
+	return
+}
+
+// CurrentOccurrence returns the occurrence time range containing the given time, or false if it's not within any time range like IsWithin().
+// The time range is clipped by the validity bounds and the exceptions, and it's the latest one if multiple time ranges overlap.
+//
+// It panics if the CronRange instance is nil or incomplete.
+func (cr *CronRange) CurrentOccurrence(t time.Time) (occur TimeRange, found bool) {
+	if !cr.IsWithin(t) {
+		return
+	}
+
+	// the latest time range starting before t contains it, or one of its parts split by the exceptions does
+	rangeStart, _ := cr.lastStart(t)
+	parts, _ := cr.occurrencesAt(rangeStart)
+	for _, part := range parts {
+		if part.Contains(t, cr.boundary) {
+			return part, true
+		}
+	}
+	return
+}
+
+// Remaining returns the duration from the given time to the end of the occurrence time range containing it,
+// or false if it's not within any time range.
+//
+// It panics if the CronRange instance is nil or incomplete.
+func (cr *CronRange) Remaining(t time.Time) (remaining time.Duration, within bool) {
+	var occur TimeRange
+	if occur, within = cr.CurrentOccurrence(t); within {
+		remaining = occur.End.Sub(t)
+	}
+	return
+}
+
+// Elapsed returns the duration from the start of the occurrence time range containing the given time to it,
+// or false if it's not within any time range.
+//
+// It panics if the CronRange instance is nil or incomplete.
+func (cr *CronRange) Elapsed(t time.Time) (elapsed time.Duration, within bool) {
+	var occur TimeRange
+	if occur, within = cr.CurrentOccurrence(t); within {
+		elapsed = t.Sub(occur.Start)
+	}
 	return
 }
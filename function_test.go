@@ -134,6 +134,357 @@ func TestCronRange_NextOccurrences(t *testing.T) {
 			},
 			false,
 		},
+		{"First day of each month for a month in Bangkok",
+			crMustParse("DR=P1M; TZ=Asia/Bangkok; 0 0 1 * *"),
+			args{firstSec2019Bangkok, 3},
+			[]TimeRange{
+				{parseTime(locationBangkok, "2019-02-01 00:00:00"), parseTime(locationBangkok, "2019-03-01 00:00:00")},
+				{parseTime(locationBangkok, "2019-03-01 00:00:00"), parseTime(locationBangkok, "2019-04-01 00:00:00")},
+				{parseTime(locationBangkok, "2019-04-01 00:00:00"), parseTime(locationBangkok, "2019-05-01 00:00:00")},
+			},
+			false,
+		},
+		{"Every 20 seconds for 5 seconds",
+			crMustParse("DR=5s; SEC=1; */20 * * * * *"),
+			args{firstSec2020Utc, 3},
+			[]TimeRange{
+				{parseTime(locationUTC, "2020-01-01 00:00:20"), parseTime(locationUTC, "2020-01-01 00:00:25")},
+				{parseTime(locationUTC, "2020-01-01 00:00:40"), parseTime(locationUTC, "2020-01-01 00:00:45")},
+				{parseTime(locationUTC, "2020-01-01 00:01:00"), parseTime(locationUTC, "2020-01-01 00:01:05")},
+			},
+			false,
+		},
+		{"Every 90 minutes since epoch",
+			crMustParse("DR=30; @every 90m"),
+			args{firstSec2020Utc.Add(10 * time.Minute), 3},
+			[]TimeRange{
+				{parseTime(locationUTC, "2020-01-01 01:30:00"), parseTime(locationUTC, "2020-01-01 02:00:00")},
+				{parseTime(locationUTC, "2020-01-01 03:00:00"), parseTime(locationUTC, "2020-01-01 03:30:00")},
+				{parseTime(locationUTC, "2020-01-01 04:30:00"), parseTime(locationUTC, "2020-01-01 05:00:00")},
+			},
+			false,
+		},
+		{"Every week by descriptor in Tokyo",
+			crMustParse("DR=1440; TZ=Asia/Tokyo; @weekly"),
+			args{firstSec2018Tokyo, 2},
+			[]TimeRange{
+				{parseTime(locationTokyo, "2018-01-07 00:00:00"), parseTime(locationTokyo, "2018-01-08 00:00:00")},
+				{parseTime(locationTokyo, "2018-01-14 00:00:00"), parseTime(locationTokyo, "2018-01-15 00:00:00")},
+			},
+			false,
+		},
+		{"Last Friday of each month in Tokyo",
+			crMustParse("DR=120; TZ=Asia/Tokyo; 0 17 * * 5L"),
+			args{firstSec2018Tokyo, 3},
+			[]TimeRange{
+				{parseTime(locationTokyo, "2018-01-26 17:00:00"), parseTime(locationTokyo, "2018-01-26 19:00:00")},
+				{parseTime(locationTokyo, "2018-02-23 17:00:00"), parseTime(locationTokyo, "2018-02-23 19:00:00")},
+				{parseTime(locationTokyo, "2018-03-30 17:00:00"), parseTime(locationTokyo, "2018-03-30 19:00:00")},
+			},
+			false,
+		},
+		{"Weekday nearest the 15th with seconds",
+			crMustParse("DR=1; SEC=1; TZ=Etc/UTC; 30 0 9 15W * *"),
+			args{firstSec2020Utc, 3},
+			[]TimeRange{
+				{parseTime(locationUTC, "2020-01-15 09:00:30"), parseTime(locationUTC, "2020-01-15 09:01:30")},
+				{parseTime(locationUTC, "2020-02-14 09:00:30"), parseTime(locationUTC, "2020-02-14 09:01:30")},
+				{parseTime(locationUTC, "2020-03-16 09:00:30"), parseTime(locationUTC, "2020-03-16 09:01:30")},
+			},
+			false,
+		},
+		{"Black Friday sale for 2025-2027 only",
+			crMustParse("DR=3d; TZ=America/New_York; 0 0 * 11 5#4 2025-2027"),
+			args{firstSec2020Utc, 5},
+			[]TimeRange{
+				{parseTime(locationUTC, "2025-11-28 05:00:00"), parseTime(locationUTC, "2025-12-01 05:00:00")},
+				{parseTime(locationUTC, "2026-11-27 05:00:00"), parseTime(locationUTC, "2026-11-30 05:00:00")},
+				{parseTime(locationUTC, "2027-11-26 05:00:00"), parseTime(locationUTC, "2027-11-29 05:00:00")},
+			},
+			false,
+		},
+		{"Daily within bounds from long before",
+			crMustParse("DR=120; TZ=Etc/UTC; NB=2020-01-02T01:00:00Z; NA=2020-01-04T00:30:00Z; 0 0 * * *"),
+			args{firstSec2019Local, 5},
+			[]TimeRange{
+				{parseTime(locationUTC, "2020-01-02 01:00:00"), parseTime(locationUTC, "2020-01-02 02:00:00")},
+				{parseTime(locationUTC, "2020-01-03 00:00:00"), parseTime(locationUTC, "2020-01-03 02:00:00")},
+				{parseTime(locationUTC, "2020-01-04 00:00:00"), parseTime(locationUTC, "2020-01-04 00:30:00")},
+			},
+			false,
+		},
+		{"Daily within bounds from the middle",
+			crMustParse("DR=120; TZ=Etc/UTC; NB=2020-01-02T01:00:00Z; NA=2020-01-04T00:30:00Z; 0 0 * * *"),
+			args{parseTime(locationUTC, "2020-01-02 01:30:00"), 5},
+			[]TimeRange{
+				{parseTime(locationUTC, "2020-01-03 00:00:00"), parseTime(locationUTC, "2020-01-03 02:00:00")},
+				{parseTime(locationUTC, "2020-01-04 00:00:00"), parseTime(locationUTC, "2020-01-04 00:30:00")},
+			},
+			false,
+		},
+		{"Daily within bounds without crossing",
+			crMustParse("DR=60; TZ=Etc/UTC; NB=2020-01-02T01:00:00Z; 0 0 * * *"),
+			args{firstSec2020Utc, 2},
+			[]TimeRange{
+				{parseTime(locationUTC, "2020-01-03 00:00:00"), parseTime(locationUTC, "2020-01-03 01:00:00")},
+				{parseTime(locationUTC, "2020-01-04 00:00:00"), parseTime(locationUTC, "2020-01-04 01:00:00")},
+			},
+			false,
+		},
+		{"Daily after bounds",
+			crMustParse("DR=60; TZ=Etc/UTC; NA=2020-01-02T01:00:00Z; 0 0 * * *"),
+			args{parseTime(locationUTC, "2020-01-02 00:00:00"), 2},
+			nil,
+			false,
+		},
+		{"First 3 Monday standups since launch",
+			crMustParse("DR=15; TZ=Etc/UTC; CNT=3; START=2025-01-06T09:00:00Z; 0 9 * * 1"),
+			args{firstSec2020Utc, 5},
+			[]TimeRange{
+				{parseTime(locationUTC, "2025-01-06 09:00:00"), parseTime(locationUTC, "2025-01-06 09:15:00")},
+				{parseTime(locationUTC, "2025-01-13 09:00:00"), parseTime(locationUTC, "2025-01-13 09:15:00")},
+				{parseTime(locationUTC, "2025-01-20 09:00:00"), parseTime(locationUTC, "2025-01-20 09:15:00")},
+			},
+			false,
+		},
+		{"Remaining Monday standups since launch",
+			crMustParse("DR=15; TZ=Etc/UTC; CNT=3; START=2025-01-06T09:00:00Z; 0 9 * * 1"),
+			args{parseTime(locationUTC, "2025-01-10 00:00:00"), 5},
+			[]TimeRange{
+				{parseTime(locationUTC, "2025-01-13 09:00:00"), parseTime(locationUTC, "2025-01-13 09:15:00")},
+				{parseTime(locationUTC, "2025-01-20 09:00:00"), parseTime(locationUTC, "2025-01-20 09:15:00")},
+			},
+			false,
+		},
+		{"No more Monday standups since launch",
+			crMustParse("DR=15; TZ=Etc/UTC; CNT=3; START=2025-01-06T09:00:00Z; 0 9 * * 1"),
+			args{parseTime(locationUTC, "2025-01-20 09:00:00"), 5},
+			nil,
+			false,
+		},
+		{"Count limit beyond year field",
+			crMustParse("DR=60; TZ=Etc/UTC; CNT=5; START=2020-01-01T00:00:00Z; 0 0 1 1 * 2021-2022"),
+			args{firstSec2020Utc, 5},
+			[]TimeRange{
+				{parseTime(locationUTC, "2021-01-01 00:00:00"), parseTime(locationUTC, "2021-01-01 01:00:00")},
+				{parseTime(locationUTC, "2022-01-01 00:00:00"), parseTime(locationUTC, "2022-01-01 01:00:00")},
+			},
+			false,
+		},
+		{"Weekends in Berlin across DST",
+			crMustParse("END=0 6 * * 1; TZ=Europe/Berlin; 0 18 * * 5"),
+			args{parseTime(locationUTC, "2019-03-23 00:00:00"), 2},
+			[]TimeRange{
+				{parseTime(locationUTC, "2019-03-29 17:00:00"), parseTime(locationUTC, "2019-04-01 04:00:00")},
+				{parseTime(locationUTC, "2019-04-05 16:00:00"), parseTime(locationUTC, "2019-04-08 04:00:00")},
+			},
+			false,
+		},
+		{"Overnight window across DST",
+			crMustParse("TZ=Europe/Berlin; Sat,Sun 22:00-06:00"),
+			args{parseTime(locationUTC, "2019-03-30 00:00:00"), 2},
+			[]TimeRange{
+				{parseTime(locationUTC, "2019-03-30 21:00:00"), parseTime(locationUTC, "2019-03-31 04:00:00")},
+				{parseTime(locationUTC, "2019-03-31 20:00:00"), parseTime(locationUTC, "2019-04-01 04:00:00")},
+			},
+			false,
+		},
+		{"Multiple cron parts for weekdays and weekends",
+			crMustParse("DR=120; TZ=Etc/UTC; 0 9 * * 1-5 | 0 11 * * 0,6"),
+			args{parseTime(locationUTC, "2020-01-03 10:00:00"), 4},
+			[]TimeRange{
+				{parseTime(locationUTC, "2020-01-04 11:00:00"), parseTime(locationUTC, "2020-01-04 13:00:00")},
+				{parseTime(locationUTC, "2020-01-05 11:00:00"), parseTime(locationUTC, "2020-01-05 13:00:00")},
+				{parseTime(locationUTC, "2020-01-06 09:00:00"), parseTime(locationUTC, "2020-01-06 11:00:00")},
+				{parseTime(locationUTC, "2020-01-07 09:00:00"), parseTime(locationUTC, "2020-01-07 11:00:00")},
+			},
+			false,
+		},
+		{"Multiple cron parts with same activations",
+			crMustParse("DR=30; TZ=Etc/UTC; 0 12 * * * | 0 */6 * * *"),
+			args{firstSec2020Utc, 3},
+			[]TimeRange{
+				{parseTime(locationUTC, "2020-01-01 06:00:00"), parseTime(locationUTC, "2020-01-01 06:30:00")},
+				{parseTime(locationUTC, "2020-01-01 12:00:00"), parseTime(locationUTC, "2020-01-01 12:30:00")},
+				{parseTime(locationUTC, "2020-01-01 18:00:00"), parseTime(locationUTC, "2020-01-01 18:30:00")},
+			},
+			false,
+		},
+		{"Multiple cron parts with one run out",
+			crMustParse("DR=60; TZ=Etc/UTC; 0 0 1 1 * 2019 | 0 0 1 * *"),
+			args{parseTime(locationUTC, "2018-12-01 00:00:00"), 3},
+			[]TimeRange{
+				{parseTime(locationUTC, "2019-01-01 00:00:00"), parseTime(locationUTC, "2019-01-01 01:00:00")},
+				{parseTime(locationUTC, "2019-02-01 00:00:00"), parseTime(locationUTC, "2019-02-01 01:00:00")},
+				{parseTime(locationUTC, "2019-03-01 00:00:00"), parseTime(locationUTC, "2019-03-01 01:00:00")},
+			},
+			false,
+		},
+		{"Weekdays except the first Monday",
+			crMustParse("DR=480; TZ=Etc/UTC; EXCEPT=[DR=1440; TZ=Etc/UTC; 0 0 * * 1#1]; 0 9 * * 1-5"),
+			args{parseTime(locationUTC, "2020-01-03 10:00:00"), 3},
+			[]TimeRange{
+				{parseTime(locationUTC, "2020-01-07 09:00:00"), parseTime(locationUTC, "2020-01-07 17:00:00")},
+				{parseTime(locationUTC, "2020-01-08 09:00:00"), parseTime(locationUTC, "2020-01-08 17:00:00")},
+				{parseTime(locationUTC, "2020-01-09 09:00:00"), parseTime(locationUTC, "2020-01-09 17:00:00")},
+			},
+			false,
+		},
+		{"Business hours split by lunch breaks",
+			crMustParse("DR=480; TZ=Etc/UTC; EXCEPT=[DR=60; TZ=Etc/UTC; 0 12 * * *]; EXCEPT=[DR=30; TZ=Etc/UTC; 0 16 * * *]; 0 9 * * *"),
+			args{firstSec2020Utc, 4},
+			[]TimeRange{
+				{parseTime(locationUTC, "2020-01-01 09:00:00"), parseTime(locationUTC, "2020-01-01 12:00:00")},
+				{parseTime(locationUTC, "2020-01-01 13:00:00"), parseTime(locationUTC, "2020-01-01 16:00:00")},
+				{parseTime(locationUTC, "2020-01-01 16:30:00"), parseTime(locationUTC, "2020-01-01 17:00:00")},
+				{parseTime(locationUTC, "2020-01-02 09:00:00"), parseTime(locationUTC, "2020-01-02 12:00:00")},
+			},
+			false,
+		},
+		{"Exception running across the start",
+			crMustParse("DR=120; TZ=Etc/UTC; EXCEPT=[DR=120; TZ=Etc/UTC; 0 23 * * *]; 0 0 * * *"),
+			args{firstSec2020Utc, 2},
+			[]TimeRange{
+				{parseTime(locationUTC, "2020-01-02 01:00:00"), parseTime(locationUTC, "2020-01-02 02:00:00")},
+				{parseTime(locationUTC, "2020-01-03 01:00:00"), parseTime(locationUTC, "2020-01-03 02:00:00")},
+			},
+			false,
+		},
+		{"Exception dropping all",
+			crMustParse("DR=60; TZ=Etc/UTC; EXCEPT=[DR=1440; TZ=Etc/UTC; 0 0 * * *]; 0 9 * * *"),
+			args{firstSec2020Utc, 2},
+			nil,
+			false,
+		},
+		{"Maintenance windows skipping holidays",
+			crMustParseWithCalendar("DR=120; TZ=Europe/Berlin; 0 2 * * 5", calendarBerlin, SkipHoliday),
+			args{parseTime(locationUTC, "2020-04-01 00:00:00"), 2},
+			[]TimeRange{
+				{parseTime(locationUTC, "2020-04-03 00:00:00"), parseTime(locationUTC, "2020-04-03 02:00:00")},
+				{parseTime(locationUTC, "2020-04-17 00:00:00"), parseTime(locationUTC, "2020-04-17 02:00:00")},
+			},
+			false,
+		},
+		{"Maintenance windows shifted from holidays",
+			crMustParseWithCalendar("DR=120; TZ=Europe/Berlin; 0 2 * * 5", calendarBerlin, ShiftHoliday),
+			args{parseTime(locationUTC, "2020-04-04 00:00:00"), 2},
+			[]TimeRange{
+				{parseTime(locationUTC, "2020-04-14 00:00:00"), parseTime(locationUTC, "2020-04-14 02:00:00")},
+				{parseTime(locationUTC, "2020-04-17 00:00:00"), parseTime(locationUTC, "2020-04-17 02:00:00")},
+			},
+			false,
+		},
+		{"Easter weekend in Berlin",
+			crMustParse("DR=P4D; TZ=Europe/Berlin; @easter-2"),
+			args{parseTime(locationUTC, "2019-01-01 00:00:00"), 2},
+			[]TimeRange{
+				{parseTime(locationUTC, "2019-04-18 22:00:00"), parseTime(locationUTC, "2019-04-22 22:00:00")},
+				{parseTime(locationUTC, "2020-04-09 22:00:00"), parseTime(locationUTC, "2020-04-13 22:00:00")},
+			},
+			false,
+		},
+		{"Black Friday and Cyber Monday",
+			crMustParse("DR=1440; TZ=America/New_York; @thanksgiving+1 | @thanksgiving+4"),
+			args{parseTime(locationUTC, "2020-01-01 00:00:00"), 3},
+			[]TimeRange{
+				{parseTime(locationUTC, "2020-11-27 05:00:00"), parseTime(locationUTC, "2020-11-28 05:00:00")},
+				{parseTime(locationUTC, "2020-11-30 05:00:00"), parseTime(locationUTC, "2020-12-01 05:00:00")},
+				{parseTime(locationUTC, "2021-11-26 05:00:00"), parseTime(locationUTC, "2021-11-27 05:00:00")},
+			},
+			false,
+		},
+		{"Every other Tuesday in Berlin",
+			crMustParse("DR=60; TZ=Europe/Berlin; EVERY=2w; ANCHOR=2025-01-07; 0 9 * * 2"),
+			args{parseTime(locationUTC, "2025-01-01 00:00:00"), 3},
+			[]TimeRange{
+				{parseTime(locationUTC, "2025-01-07 08:00:00"), parseTime(locationUTC, "2025-01-07 09:00:00")},
+				{parseTime(locationUTC, "2025-01-21 08:00:00"), parseTime(locationUTC, "2025-01-21 09:00:00")},
+				{parseTime(locationUTC, "2025-02-04 08:00:00"), parseTime(locationUTC, "2025-02-04 09:00:00")},
+			},
+			false,
+		},
+		{"Every other Tuesday in Berlin since anchor",
+			crMustParse("DR=60; TZ=Europe/Berlin; EVERY=2w; ANCHOR=2025-01-07; 0 9 * * 2"),
+			args{parseTime(locationUTC, "2024-12-01 00:00:00"), 2},
+			[]TimeRange{
+				{parseTime(locationUTC, "2025-01-07 08:00:00"), parseTime(locationUTC, "2025-01-07 09:00:00")},
+				{parseTime(locationUTC, "2025-01-21 08:00:00"), parseTime(locationUTC, "2025-01-21 09:00:00")},
+			},
+			false,
+		},
+		{"Every 3 days",
+			crMustParse("DR=30; TZ=Etc/UTC; EVERY=3d; ANCHOR=2025-01-31; 0 12 * * *"),
+			args{parseTime(locationUTC, "2025-02-01 00:00:00"), 3},
+			[]TimeRange{
+				{parseTime(locationUTC, "2025-02-03 12:00:00"), parseTime(locationUTC, "2025-02-03 12:30:00")},
+				{parseTime(locationUTC, "2025-02-06 12:00:00"), parseTime(locationUTC, "2025-02-06 12:30:00")},
+				{parseTime(locationUTC, "2025-02-09 12:00:00"), parseTime(locationUTC, "2025-02-09 12:30:00")},
+			},
+			false,
+		},
+		{"Lead time before standups",
+			crMustParse("DR=45; OFF=-30m; TZ=Europe/Berlin; 0 9 * * 1-5"),
+			args{parseTime(locationUTC, "2020-01-03 07:00:00"), 2},
+			[]TimeRange{
+				{parseTime(locationUTC, "2020-01-03 07:30:00"), parseTime(locationUTC, "2020-01-03 08:15:00")},
+				{parseTime(locationUTC, "2020-01-06 07:30:00"), parseTime(locationUTC, "2020-01-06 08:15:00")},
+			},
+			false,
+		},
+		{"Delay after the last day",
+			crMustParse("DR=60; OFF=2h; TZ=Etc/UTC; 0 0 L * *"),
+			args{firstSec2020Utc, 2},
+			[]TimeRange{
+				{parseTime(locationUTC, "2020-01-31 02:00:00"), parseTime(locationUTC, "2020-01-31 03:00:00")},
+				{parseTime(locationUTC, "2020-02-29 02:00:00"), parseTime(locationUTC, "2020-02-29 03:00:00")},
+			},
+			false,
+		},
+		{"Night shifts ending before the next start",
+			crMustParse("END=0 6 * * *; TZ=Etc/UTC; 0 22 * * 1,3"),
+			args{firstSec2020Utc, 2},
+			[]TimeRange{
+				{parseTime(locationUTC, "2020-01-01 22:00:00"), parseTime(locationUTC, "2020-01-02 06:00:00")},
+				{parseTime(locationUTC, "2020-01-06 22:00:00"), parseTime(locationUTC, "2020-01-07 06:00:00")},
+			},
+			false,
+		},
+		{"End expression never activates",
+			crMustParse("END=0 0 1 1 * 2020; TZ=Etc/UTC; 0 12 * * *"),
+			args{parseTime(locationUTC, "2019-12-30 00:00:00"), 3},
+			[]TimeRange{
+				{parseTime(locationUTC, "2019-12-30 12:00:00"), parseTime(locationUTC, "2020-01-01 00:00:00")},
+				{parseTime(locationUTC, "2019-12-31 12:00:00"), parseTime(locationUTC, "2020-01-01 00:00:00")},
+			},
+			false,
+		},
+		{"First day of each month in calendar units in Honolulu",
+			crMustParse("DR=1mo; TZ=Pacific/Honolulu; 0 0 1 * *"),
+			args{firstSec2017Honolulu, 2},
+			[]TimeRange{
+				{parseTime(locationHonolulu, "2017-02-01 00:00:00"), parseTime(locationHonolulu, "2017-03-01 00:00:00")},
+				{parseTime(locationHonolulu, "2017-03-01 00:00:00"), parseTime(locationHonolulu, "2017-04-01 00:00:00")},
+			},
+			false,
+		},
+		{"Every week in New York across DST",
+			crMustParse("DR=1w; TZ=America/New_York; 0 0 * * 0"),
+			args{parseTime(locationUTC, "2019-03-01 00:00:00"), 2},
+			[]TimeRange{
+				{parseTime(locationUTC, "2019-03-03 05:00:00"), parseTime(locationUTC, "2019-03-10 05:00:00")},
+				{parseTime(locationUTC, "2019-03-10 05:00:00"), parseTime(locationUTC, "2019-03-17 04:00:00")},
+			},
+			false,
+		},
+		{"Last day of January for a month and a half day",
+			crMustParse("DR=P1MT12H; TZ=Etc/UTC; 0 0 31 1 *"),
+			args{firstSec2020Utc, 2},
+			[]TimeRange{
+				{parseTime(locationUTC, "2020-01-31 00:00:00"), parseTime(locationUTC, "2020-02-29 12:00:00")},
+				{parseTime(locationUTC, "2021-01-31 00:00:00"), parseTime(locationUTC, "2021-02-28 12:00:00")},
+			},
+			false,
+		},
 		{"Very complicated time periods since 2017",
 			crVeryComplicated,
 			args{firstSec2017Honolulu, 5},
@@ -169,6 +520,599 @@ func BenchmarkCronRange_NextOccurrences(b *testing.B) {
 	}
 }
 
+func TestCronRange_PreviousOccurrences(t *testing.T) {
+	locationBerlin, _ := time.LoadLocation("Europe/Berlin")
+	type args struct {
+		t     time.Time
+		count int
+	}
+	tests := []struct {
+		name       string
+		cr         *CronRange
+		args       args
+		wantOccurs []TimeRange
+		wantErr    bool
+	}{
+		{"Nil struct",
+			crNil,
+			args{firstSec2019Local, 1},
+			nil,
+			true,
+		},
+		{"Incomplete struct",
+			crIncomplete,
+			args{firstSec2019Local, 1},
+			nil,
+			true,
+		},
+		{"Zero count",
+			crFirstDayEachMonth,
+			args{firstSec2019Local, 0},
+			nil,
+			true,
+		},
+		{"Every New Year's Day in Tokyo before 2020",
+			crEveryNewYearsDayTokyo,
+			args{parseTime(locationTokyo, "2020-01-01 00:00:00"), 3},
+			[]TimeRange{
+				{parseTime(locationTokyo, "2019-01-01 00:00:00"), parseTime(locationTokyo, "2019-01-02 00:00:00")},
+				{parseTime(locationTokyo, "2018-01-01 00:00:00"), parseTime(locationTokyo, "2018-01-02 00:00:00")},
+				{parseTime(locationTokyo, "2017-01-01 00:00:00"), parseTime(locationTokyo, "2017-01-02 00:00:00")},
+			},
+			false,
+		},
+		{"Ongoing one in Bangkok (UTC view)",
+			crEvery10MinBangkok,
+			args{parseTime(locationUTC, "2019-01-01 00:05:00"), 2},
+			[]TimeRange{
+				{parseTime(locationUTC, "2019-01-01 00:00:00"), parseTime(locationUTC, "2019-01-01 00:10:00")},
+				{parseTime(locationUTC, "2018-12-31 23:50:00"), parseTime(locationUTC, "2019-01-01 00:00:00")},
+			},
+			false,
+		},
+		{"Hour skipped by DST in Berlin",
+			crMustParse("DR=60; TZ=Europe/Berlin; 30 2 * * *"),
+			args{parseTime(locationBerlin, "2020-03-30 00:00:00"), 2},
+			[]TimeRange{
+				{parseTime(locationBerlin, "2020-03-28 02:30:00"), parseTime(locationBerlin, "2020-03-28 03:30:00")},
+				{parseTime(locationBerlin, "2020-03-27 02:30:00"), parseTime(locationBerlin, "2020-03-27 03:30:00")},
+			},
+			false,
+		},
+		{"Hour repeated by DST in Berlin (UTC view)",
+			crMustParse("DR=60; TZ=Europe/Berlin; 30 2 * * *"),
+			args{parseTime(locationUTC, "2020-10-25 12:00:00"), 3},
+			[]TimeRange{
+				{parseTime(locationUTC, "2020-10-25 01:30:00"), parseTime(locationUTC, "2020-10-25 02:30:00")},
+				{parseTime(locationUTC, "2020-10-25 00:30:00"), parseTime(locationUTC, "2020-10-25 01:30:00")},
+				{parseTime(locationUTC, "2020-10-24 00:30:00"), parseTime(locationUTC, "2020-10-24 01:30:00")},
+			},
+			false,
+		},
+		{"Clipped by validity bounds",
+			crMustParse("DR=120; TZ=Etc/UTC; NB=2020-01-02T01:00:00Z; NA=2020-01-04T00:30:00Z; 0 0 * * *"),
+			args{parseTime(locationUTC, "2020-01-10 00:00:00"), 5},
+			[]TimeRange{
+				{parseTime(locationUTC, "2020-01-04 00:00:00"), parseTime(locationUTC, "2020-01-04 00:30:00")},
+				{parseTime(locationUTC, "2020-01-03 00:00:00"), parseTime(locationUTC, "2020-01-03 02:00:00")},
+				{parseTime(locationUTC, "2020-01-02 01:00:00"), parseTime(locationUTC, "2020-01-02 02:00:00")},
+			},
+			false,
+		},
+		{"Limited by count",
+			crMustParse("DR=15; TZ=Etc/UTC; CNT=3; START=2025-01-06T09:00:00Z; 0 9 * * 1"),
+			args{parseTime(locationUTC, "2025-03-01 00:00:00"), 5},
+			[]TimeRange{
+				{parseTime(locationUTC, "2025-01-20 09:00:00"), parseTime(locationUTC, "2025-01-20 09:15:00")},
+				{parseTime(locationUTC, "2025-01-13 09:00:00"), parseTime(locationUTC, "2025-01-13 09:15:00")},
+				{parseTime(locationUTC, "2025-01-06 09:00:00"), parseTime(locationUTC, "2025-01-06 09:15:00")},
+			},
+			false,
+		},
+		{"Split by exception",
+			crMustParse("DR=480; TZ=Etc/UTC; EXCEPT=[DR=60; TZ=Etc/UTC; 0 12 * * *]; 0 9 * * *"),
+			args{parseTime(locationUTC, "2020-01-02 12:30:00"), 3},
+			[]TimeRange{
+				{parseTime(locationUTC, "2020-01-02 09:00:00"), parseTime(locationUTC, "2020-01-02 12:00:00")},
+				{parseTime(locationUTC, "2020-01-01 13:00:00"), parseTime(locationUTC, "2020-01-01 17:00:00")},
+				{parseTime(locationUTC, "2020-01-01 09:00:00"), parseTime(locationUTC, "2020-01-01 12:00:00")},
+			},
+			false,
+		},
+		{"End expression",
+			crMustParse("END=0 6 * * 1; TZ=Europe/Berlin; 0 18 * * 5"),
+			args{parseTime(locationBerlin, "2020-01-08 00:00:00"), 2},
+			[]TimeRange{
+				{parseTime(locationBerlin, "2020-01-03 18:00:00"), parseTime(locationBerlin, "2020-01-06 06:00:00")},
+				{parseTime(locationBerlin, "2019-12-27 18:00:00"), parseTime(locationBerlin, "2019-12-30 06:00:00")},
+			},
+			false,
+		},
+		{"Multiple expressions",
+			crMustParse("DR=30; TZ=Etc/UTC; 0 9 * * * | 0 18 * * *"),
+			args{parseTime(locationUTC, "2020-01-02 12:00:00"), 3},
+			[]TimeRange{
+				{parseTime(locationUTC, "2020-01-02 09:00:00"), parseTime(locationUTC, "2020-01-02 09:30:00")},
+				{parseTime(locationUTC, "2020-01-01 18:00:00"), parseTime(locationUTC, "2020-01-01 18:30:00")},
+				{parseTime(locationUTC, "2020-01-01 09:00:00"), parseTime(locationUTC, "2020-01-01 09:30:00")},
+			},
+			false,
+		},
+		{"Every 90 minutes",
+			crMustParse("DR=10; TZ=Etc/UTC; @every 90m"),
+			args{firstSec2020Utc, 2},
+			[]TimeRange{
+				{parseTime(locationUTC, "2019-12-31 22:30:00"), parseTime(locationUTC, "2019-12-31 22:40:00")},
+				{parseTime(locationUTC, "2019-12-31 21:00:00"), parseTime(locationUTC, "2019-12-31 21:10:00")},
+			},
+			false,
+		},
+		{"Lead time by offset",
+			crMustParse("DR=45; OFF=-30m; TZ=Europe/Berlin; 0 9 * * 1-5"),
+			args{parseTime(locationBerlin, "2020-01-06 08:30:00"), 1},
+			[]TimeRange{
+				{parseTime(locationBerlin, "2020-01-03 08:30:00"), parseTime(locationBerlin, "2020-01-03 09:15:00")},
+			},
+			false,
+		},
+		{"Easter",
+			crMustParse("DR=1440; TZ=Etc/UTC; @easter"),
+			args{firstSec2020Utc, 2},
+			[]TimeRange{
+				{parseTime(locationUTC, "2019-04-21 00:00:00"), parseTime(locationUTC, "2019-04-22 00:00:00")},
+				{parseTime(locationUTC, "2018-04-01 00:00:00"), parseTime(locationUTC, "2018-04-02 00:00:00")},
+			},
+			false,
+		},
+		{"Last day of month",
+			crMustParse("DR=60; TZ=Asia/Tokyo; 0 9 L * *"),
+			args{parseTime(locationTokyo, "2020-03-15 00:00:00"), 2},
+			[]TimeRange{
+				{parseTime(locationTokyo, "2020-02-29 09:00:00"), parseTime(locationTokyo, "2020-02-29 10:00:00")},
+				{parseTime(locationTokyo, "2020-01-31 09:00:00"), parseTime(locationTokyo, "2020-01-31 10:00:00")},
+			},
+			false,
+		},
+		{"Never happened",
+			crMustParse("DR=60; TZ=Etc/UTC; 0 0 30 2 *"),
+			args{firstSec2020Utc, 1},
+			nil,
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); (r != nil) != tt.wantErr {
+					t.Errorf("PreviousOccurrences() panic = %v, wantErr %v", r, tt.wantErr)
+				}
+			}()
+
+			gotOccurs := tt.cr.PreviousOccurrences(tt.args.t, tt.args.count)
+			if !isTimeRangeSliceEqual(gotOccurs, tt.wantOccurs) {
+				t.Errorf("PreviousOccurrences() gotOccurs = %v, want %v", gotOccurs, tt.wantOccurs)
+			}
+		})
+	}
+}
+
+func BenchmarkCronRange_PreviousOccurrences(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = crEvery10MinBangkok.PreviousOccurrences(firstSec2019Local, 10)
+	}
+}
+
+func TestCronRange_LastOccurrence(t *testing.T) {
+	tests := []struct {
+		name      string
+		cr        *CronRange
+		t         time.Time
+		wantOccur TimeRange
+		wantFound bool
+	}{
+		{"Found",
+			crEveryXmasMorningNYC,
+			firstSec2020Utc,
+			TimeRange{parseTime(locationUTC, "2019-12-25 13:00:00"), parseTime(locationUTC, "2019-12-25 17:00:00")},
+			true,
+		},
+		{"Out of bounds",
+			crMustParse("DR=60; TZ=Etc/UTC; NB=2020-01-02T00:00:00Z; 0 0 * * *"),
+			parseTime(locationUTC, "2020-01-01 12:00:00"),
+			TimeRange{},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotOccur, gotFound := tt.cr.LastOccurrence(tt.t)
+			if gotFound != tt.wantFound || !gotOccur.Start.Equal(tt.wantOccur.Start) || !gotOccur.End.Equal(tt.wantOccur.End) {
+				t.Errorf("LastOccurrence() = %v, %v, want %v, %v", gotOccur, gotFound, tt.wantOccur, tt.wantFound)
+			}
+		})
+	}
+}
+
+func TestCronRange_OccurrencesBetween(t *testing.T) {
+	type args struct {
+		from     time.Time
+		to       time.Time
+		maxCount []int
+	}
+	tests := []struct {
+		name       string
+		cr         *CronRange
+		args       args
+		wantOccurs []TimeRange
+		wantErr    bool
+	}{
+		{"Nil struct",
+			crNil,
+			args{firstSec2019Local, firstSec2020Utc, nil},
+			nil,
+			true,
+		},
+		{"Zero cap",
+			crFirstDayEachMonth,
+			args{firstSec2019Local, firstSec2020Utc, []int{0}},
+			nil,
+			true,
+		},
+		{"Negative cap",
+			crFirstDayEachMonth,
+			args{firstSec2019Local, firstSec2020Utc, []int{-1}},
+			nil,
+			true,
+		},
+		{"Empty interval",
+			crFirstDayEachMonth,
+			args{firstSec2019Local, firstSec2019Local, nil},
+			nil,
+			false,
+		},
+		{"Reversed interval",
+			crFirstDayEachMonth,
+			args{firstSec2020Utc, firstSec2019Local, nil},
+			nil,
+			false,
+		},
+		{"Overlapping ones running at the start",
+			crEveryDayWithOverlap,
+			args{parseLocalTime("2019-01-03 12:00:00"), parseLocalTime("2019-01-05 00:00:00"), nil},
+			[]TimeRange{
+				{parseLocalTime("2019-01-02 00:00:00"), parseLocalTime("2019-01-04 00:00:00")},
+				{parseLocalTime("2019-01-03 00:00:00"), parseLocalTime("2019-01-05 00:00:00")},
+				{parseLocalTime("2019-01-04 00:00:00"), parseLocalTime("2019-01-06 00:00:00")},
+			},
+			false,
+		},
+		{"Overlapping ones with cap",
+			crEveryDayWithOverlap,
+			args{parseLocalTime("2019-01-03 12:00:00"), parseLocalTime("2019-01-05 00:00:00"), []int{2}},
+			[]TimeRange{
+				{parseLocalTime("2019-01-02 00:00:00"), parseLocalTime("2019-01-04 00:00:00")},
+				{parseLocalTime("2019-01-03 00:00:00"), parseLocalTime("2019-01-05 00:00:00")},
+			},
+			false,
+		},
+		{"First day of each month in 2019",
+			crFirstDayEachMonth,
+			args{firstSec2019Local, parseLocalTime("2019-04-01 00:00:00"), []int{10}},
+			[]TimeRange{
+				{parseLocalTime("2019-01-01 00:00:00"), parseLocalTime("2019-01-02 00:00:00")},
+				{parseLocalTime("2019-02-01 00:00:00"), parseLocalTime("2019-02-02 00:00:00")},
+				{parseLocalTime("2019-03-01 00:00:00"), parseLocalTime("2019-03-02 00:00:00")},
+			},
+			false,
+		},
+		{"Clipped by validity bounds",
+			crMustParse("DR=120; TZ=Etc/UTC; NB=2020-01-02T01:00:00Z; NA=2020-01-04T00:30:00Z; 0 0 * * *"),
+			args{parseTime(locationUTC, "2020-01-01 00:00:00"), parseTime(locationUTC, "2020-01-10 00:00:00"), nil},
+			[]TimeRange{
+				{parseTime(locationUTC, "2020-01-02 01:00:00"), parseTime(locationUTC, "2020-01-02 02:00:00")},
+				{parseTime(locationUTC, "2020-01-03 00:00:00"), parseTime(locationUTC, "2020-01-03 02:00:00")},
+				{parseTime(locationUTC, "2020-01-04 00:00:00"), parseTime(locationUTC, "2020-01-04 00:30:00")},
+			},
+			false,
+		},
+		{"Split by exception",
+			crMustParse("DR=480; TZ=Etc/UTC; EXCEPT=[DR=60; TZ=Etc/UTC; 0 12 * * *]; 0 9 * * *"),
+			args{parseTime(locationUTC, "2020-01-01 12:30:00"), parseTime(locationUTC, "2020-01-02 10:00:00"), nil},
+			[]TimeRange{
+				{parseTime(locationUTC, "2020-01-01 13:00:00"), parseTime(locationUTC, "2020-01-01 17:00:00")},
+				{parseTime(locationUTC, "2020-01-02 09:00:00"), parseTime(locationUTC, "2020-01-02 12:00:00")},
+			},
+			false,
+		},
+		{"End expression running at the start",
+			crMustParse("END=0 6 * * 1; TZ=Etc/UTC; 0 18 * * 5"),
+			args{parseTime(locationUTC, "2020-01-05 00:00:00"), parseTime(locationUTC, "2020-01-11 00:00:00"), nil},
+			[]TimeRange{
+				{parseTime(locationUTC, "2020-01-03 18:00:00"), parseTime(locationUTC, "2020-01-06 06:00:00")},
+				{parseTime(locationUTC, "2020-01-10 18:00:00"), parseTime(locationUTC, "2020-01-13 06:00:00")},
+			},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); (r != nil) != tt.wantErr {
+					t.Errorf("OccurrencesBetween() panic = %v, wantErr %v", r, tt.wantErr)
+				}
+			}()
+
+			gotOccurs := tt.cr.OccurrencesBetween(tt.args.from, tt.args.to, tt.args.maxCount...)
+			if !isTimeRangeSliceEqual(gotOccurs, tt.wantOccurs) {
+				t.Errorf("OccurrencesBetween() gotOccurs = %v, want %v", gotOccurs, tt.wantOccurs)
+			}
+		})
+	}
+}
+
+func BenchmarkCronRange_OccurrencesBetween(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = crEvery10MinBangkok.OccurrencesBetween(firstSec2019Local, firstSec2019Local.Add(2*time.Hour))
+	}
+}
+
+func TestCronRange_ContainingOccurrences(t *testing.T) {
+	crOverlap := crMustParse("DR=120; TZ=Etc/UTC; */30 * * * *")
+	tests := []struct {
+		name       string
+		cr         *CronRange
+		t          time.Time
+		wantKept   []TimeRange
+		wantMerged []TimeRange
+		wantErr    bool
+	}{
+		{"Nil struct",
+			crNil,
+			firstSec2020Utc,
+			nil,
+			nil,
+			true,
+		},
+		{"Not within",
+			crEveryXmasMorningNYC,
+			parseTime(locationUTC, "2019-12-25 17:00:01"),
+			nil,
+			nil,
+			false,
+		},
+		{"Single one",
+			crEveryXmasMorningNYC,
+			parseTime(locationUTC, "2019-12-25 16:00:00"),
+			[]TimeRange{
+				{parseTime(locationUTC, "2019-12-25 13:00:00"), parseTime(locationUTC, "2019-12-25 17:00:00")},
+			},
+			[]TimeRange{
+				{parseTime(locationUTC, "2019-12-25 13:00:00"), parseTime(locationUTC, "2019-12-25 17:00:00")},
+			},
+			false,
+		},
+		{"Overlapping ones",
+			crOverlap,
+			parseTime(locationUTC, "2020-01-01 10:45:00"),
+			[]TimeRange{
+				{parseTime(locationUTC, "2020-01-01 09:00:00"), parseTime(locationUTC, "2020-01-01 11:00:00")},
+				{parseTime(locationUTC, "2020-01-01 09:30:00"), parseTime(locationUTC, "2020-01-01 11:30:00")},
+				{parseTime(locationUTC, "2020-01-01 10:00:00"), parseTime(locationUTC, "2020-01-01 12:00:00")},
+				{parseTime(locationUTC, "2020-01-01 10:30:00"), parseTime(locationUTC, "2020-01-01 12:30:00")},
+			},
+			[]TimeRange{
+				{parseTime(locationUTC, "2020-01-01 09:00:00"), parseTime(locationUTC, "2020-01-01 12:30:00")},
+			},
+			false,
+		},
+		{"Overlapping ones at the boundaries",
+			crOverlap,
+			parseTime(locationUTC, "2020-01-01 10:30:00"),
+			[]TimeRange{
+				{parseTime(locationUTC, "2020-01-01 08:30:00"), parseTime(locationUTC, "2020-01-01 10:30:00")},
+				{parseTime(locationUTC, "2020-01-01 09:00:00"), parseTime(locationUTC, "2020-01-01 11:00:00")},
+				{parseTime(locationUTC, "2020-01-01 09:30:00"), parseTime(locationUTC, "2020-01-01 11:30:00")},
+				{parseTime(locationUTC, "2020-01-01 10:00:00"), parseTime(locationUTC, "2020-01-01 12:00:00")},
+				{parseTime(locationUTC, "2020-01-01 10:30:00"), parseTime(locationUTC, "2020-01-01 12:30:00")},
+			},
+			[]TimeRange{
+				{parseTime(locationUTC, "2020-01-01 08:30:00"), parseTime(locationUTC, "2020-01-01 12:30:00")},
+			},
+			false,
+		},
+		{"Clipped by validity bounds",
+			crMustParse("DR=120; TZ=Etc/UTC; NB=2020-01-01T10:00:00Z; */30 * * * *"),
+			parseTime(locationUTC, "2020-01-01 10:15:00"),
+			[]TimeRange{
+				{parseTime(locationUTC, "2020-01-01 10:00:00"), parseTime(locationUTC, "2020-01-01 10:30:00")},
+				{parseTime(locationUTC, "2020-01-01 10:00:00"), parseTime(locationUTC, "2020-01-01 11:00:00")},
+				{parseTime(locationUTC, "2020-01-01 10:00:00"), parseTime(locationUTC, "2020-01-01 11:30:00")},
+				{parseTime(locationUTC, "2020-01-01 10:00:00"), parseTime(locationUTC, "2020-01-01 12:00:00")},
+			},
+			[]TimeRange{
+				{parseTime(locationUTC, "2020-01-01 10:00:00"), parseTime(locationUTC, "2020-01-01 12:00:00")},
+			},
+			false,
+		},
+		{"Overlapping ones with closed-open boundary",
+			crMustParse("DR=120; TZ=Etc/UTC; BD=closed-open; */30 * * * *"),
+			parseTime(locationUTC, "2020-01-01 10:30:00"),
+			[]TimeRange{
+				{parseTime(locationUTC, "2020-01-01 09:00:00"), parseTime(locationUTC, "2020-01-01 11:00:00")},
+				{parseTime(locationUTC, "2020-01-01 09:30:00"), parseTime(locationUTC, "2020-01-01 11:30:00")},
+				{parseTime(locationUTC, "2020-01-01 10:00:00"), parseTime(locationUTC, "2020-01-01 12:00:00")},
+				{parseTime(locationUTC, "2020-01-01 10:30:00"), parseTime(locationUTC, "2020-01-01 12:30:00")},
+			},
+			[]TimeRange{
+				{parseTime(locationUTC, "2020-01-01 09:00:00"), parseTime(locationUTC, "2020-01-01 12:30:00")},
+			},
+			false,
+		},
+		{"Split by exception",
+			crMustParse("DR=120; TZ=Etc/UTC; EXCEPT=[DR=10; TZ=Etc/UTC; 0 10 * * *]; */30 * * * *"),
+			parseTime(locationUTC, "2020-01-01 10:45:00"),
+			[]TimeRange{
+				{parseTime(locationUTC, "2020-01-01 10:10:00"), parseTime(locationUTC, "2020-01-01 11:00:00")},
+				{parseTime(locationUTC, "2020-01-01 10:10:00"), parseTime(locationUTC, "2020-01-01 11:30:00")},
+				{parseTime(locationUTC, "2020-01-01 10:10:00"), parseTime(locationUTC, "2020-01-01 12:00:00")},
+				{parseTime(locationUTC, "2020-01-01 10:30:00"), parseTime(locationUTC, "2020-01-01 12:30:00")},
+			},
+			[]TimeRange{
+				{parseTime(locationUTC, "2020-01-01 10:10:00"), parseTime(locationUTC, "2020-01-01 12:30:00")},
+			},
+			false,
+		},
+		{"At edge of exception",
+			crMustParse("DR=120; TZ=Etc/UTC; EXCEPT=[DR=10; TZ=Etc/UTC; 0 10 * * *]; */30 * * * *"),
+			parseTime(locationUTC, "2020-01-01 10:10:00"),
+			nil,
+			nil,
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); (r != nil) != tt.wantErr {
+					t.Errorf("ContainingOccurrences() panic = %v, wantErr %v", r, tt.wantErr)
+				}
+			}()
+
+			if gotKept := tt.cr.ContainingOccurrences(tt.t, KeepOverlaps); !isTimeRangeSliceEqual(gotKept, tt.wantKept) {
+				t.Errorf("ContainingOccurrences(KeepOverlaps) = %v, want %v", gotKept, tt.wantKept)
+			}
+			if gotMerged := tt.cr.ContainingOccurrences(tt.t, MergeOverlaps); !isTimeRangeSliceEqual(gotMerged, tt.wantMerged) {
+				t.Errorf("ContainingOccurrences(MergeOverlaps) = %v, want %v", gotMerged, tt.wantMerged)
+			}
+		})
+	}
+}
+
+func BenchmarkCronRange_ContainingOccurrences(b *testing.B) {
+	cr := crMustParse("DR=120; TZ=Etc/UTC; */30 * * * *")
+	for i := 0; i < b.N; i++ {
+		_ = cr.ContainingOccurrences(firstSec2020Utc, KeepOverlaps)
+	}
+}
+
+func TestCronRange_CurrentOccurrence(t *testing.T) {
+	tests := []struct {
+		name          string
+		cr            *CronRange
+		t             time.Time
+		wantOccur     TimeRange
+		wantFound     bool
+		wantRemaining time.Duration
+		wantElapsed   time.Duration
+		wantErr       bool
+	}{
+		{"Nil struct",
+			crNil,
+			firstSec2020Utc,
+			TimeRange{}, false, 0, 0,
+			true,
+		},
+		{"Within",
+			crEveryXmasMorningNYC,
+			parseTime(locationUTC, "2019-12-25 16:37:00"),
+			TimeRange{parseTime(locationUTC, "2019-12-25 13:00:00"), parseTime(locationUTC, "2019-12-25 17:00:00")}, true, 23 * time.Minute, 217 * time.Minute,
+			false,
+		},
+		{"At the start",
+			crEveryXmasMorningNYC,
+			parseTime(locationUTC, "2019-12-25 13:00:00"),
+			TimeRange{parseTime(locationUTC, "2019-12-25 13:00:00"), parseTime(locationUTC, "2019-12-25 17:00:00")}, true, 4 * time.Hour, 0,
+			false,
+		},
+		{"At the end",
+			crEveryXmasMorningNYC,
+			parseTime(locationUTC, "2019-12-25 17:00:00"),
+			TimeRange{parseTime(locationUTC, "2019-12-25 13:00:00"), parseTime(locationUTC, "2019-12-25 17:00:00")}, true, 0, 4 * time.Hour,
+			false,
+		},
+		{"Outside",
+			crEveryXmasMorningNYC,
+			parseTime(locationUTC, "2019-12-25 17:00:01"),
+			TimeRange{}, false, 0, 0,
+			false,
+		},
+		{"Latest of overlapping ones",
+			crEveryDayWithOverlap,
+			parseLocalTime("2019-01-03 12:00:00"),
+			TimeRange{parseLocalTime("2019-01-03 00:00:00"), parseLocalTime("2019-01-05 00:00:00")}, true, 36 * time.Hour, 12 * time.Hour,
+			false,
+		},
+		{"Clipped by validity bounds",
+			crMustParse("DR=120; TZ=Etc/UTC; NB=2020-01-02T01:00:00Z; 0 0 * * *"),
+			parseTime(locationUTC, "2020-01-02 01:30:00"),
+			TimeRange{parseTime(locationUTC, "2020-01-02 01:00:00"), parseTime(locationUTC, "2020-01-02 02:00:00")}, true, 30 * time.Minute, 30 * time.Minute,
+			false,
+		},
+		{"Split by exception",
+			crMustParse("DR=480; TZ=Etc/UTC; EXCEPT=[DR=60; TZ=Etc/UTC; 0 12 * * *]; 0 9 * * *"),
+			parseTime(locationUTC, "2020-01-02 14:00:00"),
+			TimeRange{parseTime(locationUTC, "2020-01-02 13:00:00"), parseTime(locationUTC, "2020-01-02 17:00:00")}, true, 3 * time.Hour, time.Hour,
+			false,
+		},
+		{"Within exception",
+			crMustParse("DR=480; TZ=Etc/UTC; EXCEPT=[DR=60; TZ=Etc/UTC; 0 12 * * *]; 0 9 * * *"),
+			parseTime(locationUTC, "2020-01-02 12:30:00"),
+			TimeRange{}, false, 0, 0,
+			false,
+		},
+		{"At edge of exception",
+			crMustParse("DR=480; TZ=Etc/UTC; EXCEPT=[DR=60; TZ=Etc/UTC; 0 12 * * *]; 0 9 * * *"),
+			parseTime(locationUTC, "2020-01-02 13:00:00"),
+			TimeRange{}, false, 0, 0,
+			false,
+		},
+		{"At edge of open exception",
+			crMustParse("DR=480; TZ=Etc/UTC; EXCEPT=[DR=60; TZ=Etc/UTC; BD=open; 0 12 * * *]; 0 9 * * *"),
+			parseTime(locationUTC, "2020-01-02 13:00:00"),
+			TimeRange{parseTime(locationUTC, "2020-01-02 13:00:00"), parseTime(locationUTC, "2020-01-02 17:00:00")}, true, 4 * time.Hour, 0,
+			false,
+		},
+		{"Back-to-back closed-open",
+			crMustParse("DR=60; TZ=Etc/UTC; BD=closed-open; 0 * * * *"),
+			parseTime(locationUTC, "2020-01-01 10:00:00"),
+			TimeRange{parseTime(locationUTC, "2020-01-01 10:00:00"), parseTime(locationUTC, "2020-01-01 11:00:00")}, true, time.Hour, 0,
+			false,
+		},
+		{"Back-to-back open-closed",
+			crMustParse("DR=60; TZ=Etc/UTC; BD=open-closed; 0 * * * *"),
+			parseTime(locationUTC, "2020-01-01 10:00:00"),
+			TimeRange{parseTime(locationUTC, "2020-01-01 09:00:00"), parseTime(locationUTC, "2020-01-01 10:00:00")}, true, 0, time.Hour,
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); (r != nil) != tt.wantErr {
+					t.Errorf("CurrentOccurrence() panic = %v, wantErr %v", r, tt.wantErr)
+				}
+			}()
+
+			gotOccur, gotFound := tt.cr.CurrentOccurrence(tt.t)
+			if gotFound != tt.wantFound || !gotOccur.Start.Equal(tt.wantOccur.Start) || !gotOccur.End.Equal(tt.wantOccur.End) {
+				t.Errorf("CurrentOccurrence() = %v, %v, want %v, %v", gotOccur, gotFound, tt.wantOccur, tt.wantFound)
+			}
+			if gotRemaining, gotWithin := tt.cr.Remaining(tt.t); gotRemaining != tt.wantRemaining || gotWithin != tt.wantFound {
+				t.Errorf("Remaining() = %v, %v, want %v, %v", gotRemaining, gotWithin, tt.wantRemaining, tt.wantFound)
+			}
+			if gotElapsed, gotWithin := tt.cr.Elapsed(tt.t); gotElapsed != tt.wantElapsed || gotWithin != tt.wantFound {
+				t.Errorf("Elapsed() = %v, %v, want %v, %v", gotElapsed, gotWithin, tt.wantElapsed, tt.wantFound)
+			}
+		})
+	}
+}
+
+func BenchmarkCronRange_CurrentOccurrence(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = crEvery10MinBangkok.CurrentOccurrence(firstSec2019Local)
+	}
+}
+
 func TestCronRange_IsWithin(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -186,6 +1130,17 @@ func TestCronRange_IsWithin(t *testing.T) {
 		{"Every 3rd minute - out3", "DR=1; */3 * * * *", parseLocalTime("2019-01-01 01:01:01"), false, false},
 		{"Every 3rd minute - lower", "DR=1; */3 * * * *", parseLocalTime("2019-01-01 01:00:00"), true, false},
 		{"Every 3rd minute - upper", "DR=1; */3 * * * *", parseLocalTime("2019-01-01 01:01:00"), true, false},
+		{"Every minute for 30 seconds - in", "DR=30s; * * * * *", parseLocalTime("2019-01-01 01:00:29"), true, false},
+		{"Every minute for 30 seconds - upper", "DR=30s; * * * * *", parseLocalTime("2019-01-01 01:00:30"), true, false},
+		{"Every minute for 30 seconds - out", "DR=30s; * * * * *", parseLocalTime("2019-01-01 01:00:31"), false, false},
+		{"Every 9:00:30 for a minute - lower", "DR=1; SEC=1; 30 0 9 * * *", parseLocalTime("2019-01-01 09:00:30"), true, false},
+		{"Every 9:00:30 for a minute - upper", "DR=1; SEC=1; 30 0 9 * * *", parseLocalTime("2019-01-01 09:01:30"), true, false},
+		{"Every 9:00:30 for a minute - out1", "DR=1; SEC=1; 30 0 9 * * *", parseLocalTime("2019-01-01 09:00:29"), false, false},
+		{"Every 9:00:30 for a minute - out2", "DR=1; SEC=1; 30 0 9 * * *", parseLocalTime("2019-01-01 09:01:31"), false, false},
+		{"Every day by descriptor - in", "DR=60; @daily", parseLocalTime("2019-01-01 00:30:00"), true, false},
+		{"Every day by descriptor - out", "DR=60; @daily", parseLocalTime("2019-01-01 01:30:00"), false, false},
+		{"Every 90 minutes since epoch - in", "DR=30; @every 90m", parseTime(locationUTC, "2019-01-01 01:45:00"), true, false},
+		{"Every 90 minutes since epoch - out", "DR=30; @every 90m", parseTime(locationUTC, "2019-01-01 01:15:00"), false, false},
 		{"Every 3rd hour - in1", "DR=60; 0 */3 * * *", parseLocalTime("2019-01-01 00:25:00"), true, false},
 		{"Every 3rd hour - in2", "DR=60; 0 */3 * * *", parseLocalTime("2019-01-01 00:55:00"), true, false},
 		{"Every 3rd hour - out1", "DR=60; 0 */3 * * *", parseLocalTime("2019-01-01 01:00:01"), false, false},
@@ -205,6 +1160,76 @@ func TestCronRange_IsWithin(t *testing.T) {
 		{"Every New Year's Day - in", "DR=1440; 0 0 1 1 *", parseLocalTime("2019-01-01 12:34:56"), true, false},
 		{"Every New Year's Day - out1", "DR=1440; 0 0 1 1 *", parseLocalTime("2019-02-01 12:34:56"), false, false},
 		{"Every New Year's Day - out2", "DR=1440; 0 0 1 1 *", parseLocalTime("2019-01-02 00:00:01"), false, false},
+		{"Every month for a month - in1", "DR=P1M; 0 0 1 * *", parseLocalTime("2019-02-28 23:59:59"), true, false},
+		{"Every month for a month - in2", "DR=P1M; 0 0 1 * *", parseLocalTime("2019-03-31 23:59:59"), true, false},
+		{"Every 15th for a month - in", "DR=P1M; 0 0 15 * *", parseLocalTime("2019-03-14 23:59:59"), true, false},
+		{"Every 15th of January for a month - in", "DR=P1M; 0 0 15 1 *", parseLocalTime("2019-02-15 00:00:00"), true, false},
+		{"Every 15th of January for a month - out", "DR=P1M; 0 0 15 1 *", parseLocalTime("2019-02-15 00:00:01"), false, false},
+		{"Every Monday for a week - in", "DR=1w; 0 0 * * 1", parseLocalTime("2019-01-06 23:59:59"), true, false},
+		{"Every Monday of January for a week - out", "DR=1w; 0 0 * 1 1", parseLocalTime("2019-02-04 00:00:01"), false, false},
+		{"Every leap day for a year - in", "DR=1y; 0 0 29 2 *", parseLocalTime("2021-02-27 23:59:59"), true, false},
+		{"Every leap day for a year - upper", "DR=1y; 0 0 29 2 *", parseLocalTime("2021-02-28 00:00:00"), true, false},
+		{"Every leap day for a year - out", "DR=1y; 0 0 29 2 *", parseLocalTime("2021-02-28 00:00:01"), false, false},
+		{"Every DST start day in New York - in", "DR=P1D; TZ=America/New_York; 0 0 10 3 *", parseTime(locationUTC, "2019-03-11 03:59:59"), true, false},
+		{"Every DST start day in New York - upper", "DR=P1D; TZ=America/New_York; 0 0 10 3 *", parseTime(locationUTC, "2019-03-11 04:00:00"), true, false},
+		{"Every DST start day in New York - out", "DR=P1D; TZ=America/New_York; 0 0 10 3 *", parseTime(locationUTC, "2019-03-11 04:00:01"), false, false},
+		{"Every DST start day for 24 hours in New York - in", "DR=PT24H; TZ=America/New_York; 0 0 10 3 *", parseTime(locationUTC, "2019-03-11 04:59:59"), true, false},
+		{"Every last day of month - in", "DR=1440; 0 0 L * *", parseLocalTime("2019-02-28 12:00:00"), true, false},
+		{"Every last day of month - out", "DR=1440; 0 0 L * *", parseLocalTime("2019-02-27 12:00:00"), false, false},
+		{"Every second Monday - in", "DR=60; 0 10 * * 1#2", parseLocalTime("2019-01-14 10:30:00"), true, false},
+		{"Every second Monday - out", "DR=60; 0 10 * * 1#2", parseLocalTime("2019-01-07 10:30:00"), false, false},
+		{"Every New Year's Day in 2019-2020 - in", "DR=1440; 0 0 1 1 * 2019-2020", parseLocalTime("2020-01-01 12:00:00"), true, false},
+		{"Every New Year's Day in 2019-2020 - out", "DR=1440; 0 0 1 1 * 2019-2020", parseLocalTime("2021-01-01 12:00:00"), false, false},
+		{"Back-to-back closed - top of hour", "DR=60; TZ=Etc/UTC; 0 * * * *", parseTime(locationUTC, "2020-01-01 10:00:00"), true, false},
+		{"Back-to-back closed-open - top of hour", "DR=60; TZ=Etc/UTC; BD=closed-open; 0 * * * *", parseTime(locationUTC, "2020-01-01 10:00:00"), true, false},
+		{"Back-to-back open-closed - top of hour", "DR=60; TZ=Etc/UTC; BD=open-closed; 0 * * * *", parseTime(locationUTC, "2020-01-01 10:00:00"), true, false},
+		{"Back-to-back open - top of hour", "DR=60; TZ=Etc/UTC; BD=open; 0 * * * *", parseTime(locationUTC, "2020-01-01 10:00:00"), false, false},
+		{"Back-to-back open - in", "DR=60; TZ=Etc/UTC; BD=open; 0 * * * *", parseTime(locationUTC, "2020-01-01 10:00:01"), true, false},
+		{"Daily closed-open - end", "DR=60; TZ=Etc/UTC; BD=closed-open; 0 9 * * *", parseTime(locationUTC, "2020-01-01 10:00:00"), false, false},
+		{"Daily open-closed - start", "DR=60; TZ=Etc/UTC; BD=open-closed; 0 9 * * *", parseTime(locationUTC, "2020-01-01 09:00:00"), false, false},
+		{"Daily open-closed - end", "DR=60; TZ=Etc/UTC; BD=open-closed; 0 9 * * *", parseTime(locationUTC, "2020-01-01 10:00:00"), true, false},
+		{"Overlapping every 30 minutes - in", "DR=120; TZ=Etc/UTC; */30 * * * *", parseTime(locationUTC, "2020-01-01 10:45:00"), true, false},
+		{"Overlapping every 30 minutes - in exception", "DR=120; TZ=Etc/UTC; EXCEPT=[DR=10; TZ=Etc/UTC; 0 10 * * *]; */30 * * * *", parseTime(locationUTC, "2020-01-01 10:05:00"), false, false},
+		{"Every day in campaign - in", "DR=60; TZ=Etc/UTC; NB=2019-01-02T00:30:00Z; NA=2019-01-05T00:00:00Z; 0 0 * * *", parseTime(locationUTC, "2019-01-02 00:30:00"), true, false},
+		{"Every day in campaign - out1", "DR=60; TZ=Etc/UTC; NB=2019-01-02T00:30:00Z; NA=2019-01-05T00:00:00Z; 0 0 * * *", parseTime(locationUTC, "2019-01-02 00:29:59"), false, false},
+		{"Every day in campaign - out2", "DR=60; TZ=Etc/UTC; NB=2019-01-02T00:30:00Z; NA=2019-01-05T00:00:00Z; 0 0 * * *", parseTime(locationUTC, "2019-01-01 00:30:00"), false, false},
+		{"Every day in campaign - upper", "DR=60; TZ=Etc/UTC; NB=2019-01-02T00:30:00Z; NA=2019-01-05T00:00:00Z; 0 0 * * *", parseTime(locationUTC, "2019-01-05 00:00:00"), true, false},
+		{"Every day in campaign - out3", "DR=60; TZ=Etc/UTC; NB=2019-01-02T00:30:00Z; NA=2019-01-05T00:00:00Z; 0 0 * * *", parseTime(locationUTC, "2019-01-05 00:00:01"), false, false},
+		{"First 2 Mondays - before", "DR=60; TZ=Etc/UTC; CNT=2; START=2019-01-07T00:00:00Z; 0 9 * * 1", parseTime(locationUTC, "2018-12-31 09:30:00"), false, false},
+		{"First 2 Mondays - in1", "DR=60; TZ=Etc/UTC; CNT=2; START=2019-01-07T00:00:00Z; 0 9 * * 1", parseTime(locationUTC, "2019-01-07 09:30:00"), true, false},
+		{"First 2 Mondays - in2", "DR=60; TZ=Etc/UTC; CNT=2; START=2019-01-07T00:00:00Z; 0 9 * * 1", parseTime(locationUTC, "2019-01-14 09:30:00"), true, false},
+		{"First 2 Mondays - after", "DR=60; TZ=Etc/UTC; CNT=2; START=2019-01-07T00:00:00Z; 0 9 * * 1", parseTime(locationUTC, "2019-01-21 09:30:00"), false, false},
+		{"Business hours in Berlin - in", "TZ=Europe/Berlin; Mon-Fri 09:00-17:00", parseTime(locationUTC, "2019-04-01 07:00:00"), true, false},
+		{"Business hours in Berlin - out", "TZ=Europe/Berlin; Mon-Fri 09:00-17:00", parseTime(locationUTC, "2019-04-01 15:00:01"), false, false},
+		{"Business hours in Berlin - weekend", "TZ=Europe/Berlin; Mon-Fri 09:00-17:00", parseTime(locationUTC, "2019-03-31 10:00:00"), false, false},
+		{"Weekdays and weekends - weekday in", "DR=120; TZ=Etc/UTC; 0 9 * * 1-5 | 0 11 * * 0,6", parseTime(locationUTC, "2020-01-06 10:59:59"), true, false},
+		{"Weekdays and weekends - weekday out", "DR=120; TZ=Etc/UTC; 0 9 * * 1-5 | 0 11 * * 0,6", parseTime(locationUTC, "2020-01-06 12:00:00"), false, false},
+		{"Weekdays and weekends - weekend in", "DR=120; TZ=Etc/UTC; 0 9 * * 1-5 | 0 11 * * 0,6", parseTime(locationUTC, "2020-01-05 12:00:00"), true, false},
+		{"Weekdays and weekends - weekend out", "DR=120; TZ=Etc/UTC; 0 9 * * 1-5 | 0 11 * * 0,6", parseTime(locationUTC, "2020-01-05 10:00:00"), false, false},
+		{"Except first Monday - in", "DR=480; TZ=Etc/UTC; EXCEPT=[DR=1440; TZ=Etc/UTC; 0 0 * * 1#1]; 0 9 * * 1-5", parseTime(locationUTC, "2020-01-13 10:00:00"), true, false},
+		{"Except first Monday - excluded", "DR=480; TZ=Etc/UTC; EXCEPT=[DR=1440; TZ=Etc/UTC; 0 0 * * 1#1]; 0 9 * * 1-5", parseTime(locationUTC, "2020-01-06 10:00:00"), false, false},
+		{"Except lunch break - excluded", "DR=480; TZ=Etc/UTC; EXCEPT=[DR=60; TZ=Etc/UTC; 0 12 * * *]; 0 9 * * *", parseTime(locationUTC, "2020-01-06 12:30:00"), false, false},
+		{"Except lunch break - before edge", "DR=480; TZ=Etc/UTC; EXCEPT=[DR=60; TZ=Etc/UTC; 0 12 * * *]; 0 9 * * *", parseTime(locationUTC, "2020-01-06 11:59:59"), true, false},
+		{"Except lunch break - starting edge", "DR=480; TZ=Etc/UTC; EXCEPT=[DR=60; TZ=Etc/UTC; 0 12 * * *]; 0 9 * * *", parseTime(locationUTC, "2020-01-06 12:00:00"), false, false},
+		{"Except lunch break - ending edge", "DR=480; TZ=Etc/UTC; EXCEPT=[DR=60; TZ=Etc/UTC; 0 12 * * *]; 0 9 * * *", parseTime(locationUTC, "2020-01-06 13:00:00"), false, false},
+		{"Except lunch break - after edge", "DR=480; TZ=Etc/UTC; EXCEPT=[DR=60; TZ=Etc/UTC; 0 12 * * *]; 0 9 * * *", parseTime(locationUTC, "2020-01-06 13:00:01"), true, false},
+		{"Except open lunch break - starting edge", "DR=480; TZ=Etc/UTC; EXCEPT=[DR=60; TZ=Etc/UTC; BD=open; 0 12 * * *]; 0 9 * * *", parseTime(locationUTC, "2020-01-06 12:00:00"), true, false},
+		{"Except open lunch break - ending edge", "DR=480; TZ=Etc/UTC; EXCEPT=[DR=60; TZ=Etc/UTC; BD=open; 0 12 * * *]; 0 9 * * *", parseTime(locationUTC, "2020-01-06 13:00:00"), true, false},
+		{"Two centuries - in", "DR=200y; TZ=Etc/UTC; 0 0 1 1 *", parseTime(locationUTC, "2025-06-01 00:00:00"), true, false},
+		{"Two centuries in ISO - in", "DR=P200Y; TZ=Etc/UTC; 0 0 1 1 *", parseTime(locationUTC, "2025-06-01 00:00:00"), true, false},
+		{"Easter weekend - in", "DR=P4D; TZ=Europe/Berlin; @easter-2", parseTime(locationUTC, "2020-04-12 12:00:00"), true, false},
+		{"Easter weekend - out", "DR=P4D; TZ=Europe/Berlin; @easter-2", parseTime(locationUTC, "2020-04-14 12:00:00"), false, false},
+		{"Every other Tuesday - in", "DR=60; TZ=Europe/Berlin; EVERY=2w; ANCHOR=2025-01-07; 0 9 * * 2", parseTime(locationUTC, "2025-01-21 08:30:00"), true, false},
+		{"Every other Tuesday - before anchor", "DR=60; TZ=Europe/Berlin; EVERY=2w; ANCHOR=2025-01-07; 0 9 * * 2", parseTime(locationUTC, "2024-12-24 08:30:00"), false, false},
+		{"Every other Tuesday - skipped", "DR=60; TZ=Europe/Berlin; EVERY=2w; ANCHOR=2025-01-07; 0 9 * * 2", parseTime(locationUTC, "2025-01-14 08:30:00"), false, false},
+		{"Lead time - in", "DR=45; OFF=-30m; TZ=Etc/UTC; 0 9 * * *", parseTime(locationUTC, "2020-01-03 08:40:00"), true, false},
+		{"Lead time - before", "DR=45; OFF=-30m; TZ=Etc/UTC; 0 9 * * *", parseTime(locationUTC, "2020-01-03 08:29:59"), false, false},
+		{"Lead time - after", "DR=45; OFF=-30m; TZ=Etc/UTC; 0 9 * * *", parseTime(locationUTC, "2020-01-03 09:15:01"), false, false},
+		{"Weekend in Berlin - in1", "END=0 6 * * 1; TZ=Europe/Berlin; 0 18 * * 5", parseTime(locationUTC, "2019-03-29 17:00:00"), true, false},
+		{"Weekend in Berlin - in2", "END=0 6 * * 1; TZ=Europe/Berlin; 0 18 * * 5", parseTime(locationUTC, "2019-03-31 12:00:00"), true, false},
+		{"Weekend in Berlin - upper", "END=0 6 * * 1; TZ=Europe/Berlin; 0 18 * * 5", parseTime(locationUTC, "2019-04-01 04:00:00"), true, false},
+		{"Weekend in Berlin - out1", "END=0 6 * * 1; TZ=Europe/Berlin; 0 18 * * 5", parseTime(locationUTC, "2019-04-01 04:00:01"), false, false},
+		{"Weekend in Berlin - out2", "END=0 6 * * 1; TZ=Europe/Berlin; 0 18 * * 5", parseTime(locationUTC, "2019-03-29 16:59:59"), false, false},
 		{"Every New Year's Day in Bangkok - in1", "DR=1440; TZ=Asia/Bangkok; 0 0 1 1 *", parseTime(locationBangkok, "2019-01-01 12:34:56"), true, false},
 		{"Every New Year's Day in Bangkok - in2", "DR=1440; TZ=Asia/Bangkok; 0 0 1 1 *", parseTime(locationBangkok, "2019-01-02 00:00:00"), true, false},
 		{"Every New Year's Day in Bangkok - in3", "DR=1440; TZ=Asia/Bangkok; 0 0 1 1 *", parseTime(locationUTC, "2019-01-01 17:00:00"), true, false},
@@ -246,6 +1271,32 @@ func TestCronRange_IsWithin(t *testing.T) {
 	}
 }
 
+func TestCronRange_IsWithin_Calendar(t *testing.T) {
+	crSkip := crMustParseWithCalendar("DR=120; TZ=Europe/Berlin; 0 2 * * 5", calendarBerlin, SkipHoliday)
+	crShift := crMustParseWithCalendar("DR=120; TZ=Europe/Berlin; 0 2 * * 5", calendarBerlin, ShiftHoliday)
+	tests := []struct {
+		name       string
+		cr         *CronRange
+		t          time.Time
+		wantWithin bool
+	}{
+		{"Skip - business day", crSkip, parseTime(locationUTC, "2020-04-03 01:00:00"), true},
+		{"Skip - holiday", crSkip, parseTime(locationUTC, "2020-04-10 01:00:00"), false},
+		{"Skip - next business day", crSkip, parseTime(locationUTC, "2020-04-14 01:00:00"), false},
+		{"Shift - business day", crShift, parseTime(locationUTC, "2020-04-03 01:00:00"), true},
+		{"Shift - holiday", crShift, parseTime(locationUTC, "2020-04-10 01:00:00"), false},
+		{"Shift - next business day", crShift, parseTime(locationUTC, "2020-04-14 01:00:00"), true},
+		{"Shift - after next business day", crShift, parseTime(locationUTC, "2020-04-14 02:00:01"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if gotWithin := tt.cr.IsWithin(tt.t); gotWithin != tt.wantWithin {
+				t.Errorf("IsWithin() gotWithin = %v, want %v", gotWithin, tt.wantWithin)
+			}
+		})
+	}
+}
+
 func BenchmarkCronRange_IsWithin(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		_ = crEvery10MinBangkok.IsWithin(firstSec2019Bangkok)
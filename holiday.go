@@ -0,0 +1,159 @@
+package cronrange
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dateRule computes the date of a movable feast or holiday in the given year.
+type dateRule func(year int) (month time.Month, day int)
+
+var (
+	// dateRules lists the named date rules which can be used like descriptors, e.g. "@easter" or "@thanksgiving+1".
+	dateRules = map[string]dateRule{
+		"easter":          westernEaster,
+		"orthodox-easter": orthodoxEaster,
+		"thanksgiving":    nthWeekdayOf(time.November, time.Thursday, 4),
+		"memorial-day":    lastWeekdayOf(time.May, time.Monday),
+		"labor-day":       nthWeekdayOf(time.September, time.Monday, 1),
+		"mothers-day":     nthWeekdayOf(time.May, time.Sunday, 2),
+		"fathers-day":     nthWeekdayOf(time.June, time.Sunday, 3),
+	}
+)
+
+// maxDateRuleOffset is the upper bound of the absolute value of days offset from the date rules.
+const maxDateRuleOffset = 366
+
+// westernEaster returns the date of Easter Sunday in Gregorian calendar, with the anonymous Gregorian algorithm.
+func westernEaster(year int) (time.Month, int) {
+	a := year % 19
+	b, c := year/100, year%100
+	d, e := b/4, b%4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i, k := c/4, c%4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return time.Month(month), day
+}
+
+// orthodoxEaster returns the date of Orthodox Easter Sunday in Gregorian calendar, with the Meeus Julian algorithm,
+// the difference between Julian and Gregorian calendars is 13 days from 1900 to 2099.
+func orthodoxEaster(year int) (time.Month, int) {
+	a, b, c := year%4, year%7, year%19
+	d := (19*c + 15) % 30
+	e := (2*a + 4*b - d + 34) % 7
+	month := (d + e + 114) / 31
+	day := (d+e+114)%31 + 1
+	date := time.Date(year, time.Month(month), day+13, 0, 0, 0, 0, time.UTC)
+	return date.Month(), date.Day()
+}
+
+// nthWeekdayOf returns the date rule of the nth weekday of the month, e.g. the 4th Thursday of November.
+func nthWeekdayOf(month time.Month, weekday time.Weekday, nth int) dateRule {
+	return func(year int) (time.Month, int) {
+		first := weekdayOf(year, month, 1)
+		return month, 1 + (int(weekday)-int(first)+7)%7 + (nth-1)*7
+	}
+}
+
+// lastWeekdayOf returns the date rule of the last weekday of the month, e.g. the last Monday of May.
+func lastWeekdayOf(month time.Month, weekday time.Weekday) dateRule {
+	return func(year int) (time.Month, int) {
+		last := daysIn(year, month)
+		return month, last - (int(weekdayOf(year, month, last))-int(weekday)+7)%7
+	}
+}
+
+// isDateRule checks if the expression starts with a name of date rules.
+func isDateRule(expr string) bool {
+	name, _ := splitDateRule(expr)
+	_, found := dateRules[name]
+	return found
+}
+
+// splitDateRule splits the expression like "@easter-2" into the lower-cased name and the offset part.
+func splitDateRule(expr string) (name, offset string) {
+	if !strings.HasPrefix(expr, "@") {
+		return
+	}
+	name = strings.ToLower(expr[1:])
+	if idx := strings.LastIndexAny(name, "+-"); idx > 0 {
+		if _, err := strconv.Atoi(name[idx+1:]); err == nil {
+			name, offset = name[:idx], name[idx:]
+		}
+	}
+	return
+}
+
+// parseDateRuleSchedule parses the date rule with an optional offset of days like "@easter-2" or "@thanksgiving+1",
+// which activates at the midnight of the date in the time zone.
+func parseDateRuleSchedule(expr, timeZone string) (sched dateRuleSchedule, err error) {
+	name, offsetStr := splitDateRule(expr)
+	rule, found := dateRules[name]
+	if !found {
+		err = fmt.Errorf("unknown date rule: %q", expr)
+		return
+	}
+	sched.rule = rule
+
+	if offsetStr != "" {
+		if sched.offset, err = strconv.Atoi(offsetStr); err != nil || sched.offset > maxDateRuleOffset || sched.offset < -maxDateRuleOffset {
+			err = fmt.Errorf("invalid offset of date rule: %q", expr)
+			return
+		}
+	}
+	if len(timeZone) > 0 {
+		sched.loc, err = time.LoadLocation(timeZone)
+	}
+	return
+}
+
+// dateRuleSchedule activates at the midnight of the date computed by the rule every year, in the location or the location of given time if it's nil.
+type dateRuleSchedule struct {
+	rule   dateRule
+	offset int
+	loc    *time.Location
+}
+
+// Next returns the next activation time, later than the given time.
+// If no time can be found within five years, it returns the zero time like cron.SpecSchedule.
+func (s dateRuleSchedule) Next(t time.Time) time.Time {
+	loc := s.loc
+	if loc == nil {
+		loc = t.Location()
+	}
+
+	// the offset may move the date into the adjacent years
+	year := t.In(loc).Year()
+	for y := year - 1; y <= year+5; y++ {
+		month, day := s.rule(y)
+		if next := time.Date(y, month, day+s.offset, 0, 0, 0, 0, loc); next.After(t) {
+			return next.In(t.Location())
+		}
+	}
+	return time.Time{}
+}
+
+// Prev returns the previous activation time, earlier than the given time.
+// If no time can be found within five years, it returns the zero time.
+func (s dateRuleSchedule) Prev(t time.Time) time.Time {
+	loc := s.loc
+	if loc == nil {
+		loc = t.Location()
+	}
+
+	year := t.In(loc).Year()
+	for y := year + 1; y >= year-5; y-- {
+		month, day := s.rule(y)
+		if prev := time.Date(y, month, day+s.offset, 0, 0, 0, 0, loc); prev.Before(t) {
+			return prev.In(t.Location())
+		}
+	}
+	return time.Time{}
+}
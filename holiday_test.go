@@ -0,0 +1,113 @@
+package cronrange
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDateRules(t *testing.T) {
+	tests := []struct {
+		name string
+		year int
+		want string
+	}{
+		{"easter", 2019, "04-21"},
+		{"easter", 2020, "04-12"},
+		{"easter", 2024, "03-31"},
+		{"easter", 2025, "04-20"},
+		{"easter", 2038, "04-25"},
+		{"orthodox-easter", 2019, "04-28"},
+		{"orthodox-easter", 2020, "04-19"},
+		{"orthodox-easter", 2024, "05-05"},
+		{"orthodox-easter", 2025, "04-20"},
+		{"thanksgiving", 2019, "11-28"},
+		{"thanksgiving", 2020, "11-26"},
+		{"thanksgiving", 2025, "11-27"},
+		{"memorial-day", 2019, "05-27"},
+		{"memorial-day", 2020, "05-25"},
+		{"memorial-day", 2021, "05-31"},
+		{"labor-day", 2020, "09-07"},
+		{"labor-day", 2025, "09-01"},
+		{"mothers-day", 2020, "05-10"},
+		{"fathers-day", 2020, "06-21"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			month, day := dateRules[tt.name](tt.year)
+			if got := time.Date(tt.year, month, day, 0, 0, 0, 0, time.UTC).Format("01-02"); got != tt.want {
+				t.Errorf("%s of %d = %s, want %s", tt.name, tt.year, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDateRuleSchedule(t *testing.T) {
+	tests := []struct {
+		name       string
+		expr       string
+		timeZone   string
+		wantOffset int
+		wantErr    bool
+	}{
+		{"Unknown name", "@christmas", emptyString, 0, true},
+		{"Invalid offset", "@easter+400", emptyString, 0, true},
+		{"Invalid time zone", "@easter", "Mars", 0, true},
+		{"Plain", "@easter", emptyString, 0, false},
+		{"Upper case", "@Thanksgiving", timeZoneNewYork, 0, false},
+		{"Positive offset", "@thanksgiving+1", timeZoneNewYork, 1, false},
+		{"Negative offset", "@orthodox-easter-7", timeZoneUTC, -7, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseDateRuleSchedule(tt.expr, tt.timeZone)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseDateRuleSchedule() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.offset != tt.wantOffset {
+				t.Errorf("parseDateRuleSchedule() got offset = %v, want %v", got.offset, tt.wantOffset)
+			}
+		})
+	}
+}
+
+func TestDateRuleSchedule_Next(t *testing.T) {
+	tests := []struct {
+		name     string
+		expr     string
+		timeZone string
+		t        time.Time
+		want     time.Time
+	}{
+		{"Easter before", "@easter", timeZoneUTC, parseTime(locationUTC, "2020-01-01 00:00:00"), parseTime(locationUTC, "2020-04-12 00:00:00")},
+		{"Easter at", "@easter", timeZoneUTC, parseTime(locationUTC, "2020-04-12 00:00:00"), parseTime(locationUTC, "2021-04-04 00:00:00")},
+		{"Good Friday", "@easter-2", timeZoneUTC, parseTime(locationUTC, "2020-04-10 00:00:00"), parseTime(locationUTC, "2021-04-02 00:00:00")},
+		{"Offset into next year", "@thanksgiving+40", timeZoneUTC, parseTime(locationUTC, "2020-12-31 00:00:00"), parseTime(locationUTC, "2021-01-05 00:00:00")},
+		{"Offset from last year", "@easter-120", timeZoneUTC, parseTime(locationUTC, "2019-12-01 00:00:00"), parseTime(locationUTC, "2019-12-14 00:00:00")},
+		{"In time zone", "@thanksgiving", timeZoneNewYork, parseTime(locationUTC, "2020-11-01 00:00:00"), parseTime(locationUTC, "2020-11-26 05:00:00")},
+		{"In location of given time", "@easter", emptyString, parseTime(locationTokyo, "2020-01-01 00:00:00"), parseTime(locationTokyo, "2020-04-12 00:00:00")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := parseDateRuleSchedule(tt.expr, tt.timeZone)
+			if err != nil {
+				t.Errorf("parseDateRuleSchedule() error = %v", err)
+				return
+			}
+			got := s.Next(tt.t)
+			if !got.Equal(tt.want) {
+				t.Errorf("Next() = %v, want %v", got, tt.want)
+			}
+			if got.Location() != tt.t.Location() {
+				t.Errorf("Next() location = %v, want %v", got.Location(), tt.t.Location())
+			}
+		})
+	}
+}
+
+func BenchmarkDateRuleSchedule_Next(b *testing.B) {
+	s, _ := parseDateRuleSchedule("@easter-2", timeZoneUTC)
+	for i := 0; i < b.N; i++ {
+		_ = s.Next(firstSec2020Utc)
+	}
+}
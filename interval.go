@@ -0,0 +1,116 @@
+package cronrange
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+var (
+	errInvalidInterval      = errors.New("interval should be a positive number of days or weeks like 3d or 2w")
+	errIntervalAnchorUnpair = errors.New("interval and anchor date should be both set")
+)
+
+// interval filters the activations by the periods counted from the anchor date, e.g. "every other week" or "every 3 days".
+type interval struct {
+	count  int
+	weeks  bool
+	anchor time.Time
+}
+
+func (iv interval) isZero() bool {
+	return iv.count == 0
+}
+
+// unitDays returns the number of days in a unit of the interval.
+func (iv interval) unitDays() int {
+	if iv.weeks {
+		return 7
+	}
+	return 1
+}
+
+// parseInterval parses the value of EVERY= part like "3d" or "2w".
+func parseInterval(s string) (count int, weeks bool, err error) {
+	var numStr string
+	switch {
+	case strings.HasSuffix(s, "d"):
+		numStr = s[:len(s)-1]
+	case strings.HasSuffix(s, "w"):
+		numStr, weeks = s[:len(s)-1], true
+	default:
+		err = fmt.Errorf("%w: %q", errInvalidInterval, s)
+		return
+	}
+	if count, err = strconv.Atoi(numStr); err != nil || count <= 0 {
+		err = fmt.Errorf("%w: %q", errInvalidInterval, s)
+	}
+	return
+}
+
+// formatInterval returns the value of EVERY= part.
+func formatInterval(count int, weeks bool) string {
+	if weeks {
+		return strconv.Itoa(count) + "w"
+	}
+	return strconv.Itoa(count) + "d"
+}
+
+// daysSince returns the number of calendar days from the anchor date to the date of given time.
+func daysSince(anchor, t time.Time) int {
+	year, month, day := t.Date()
+	date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	return int(date.Sub(anchor).Hours()) / 24
+}
+
+// floorDiv returns the quotient rounded towards negative infinity.
+func floorDiv(a, b int) int {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+	return q
+}
+
+// intervalSchedule keeps the activations of the inner schedule falling on the first unit of each interval counted from the anchor date,
+// e.g. the activations in the 1st, 3rd, 5th... weeks from the anchor date for an interval of two weeks, and drops the ones before the anchor date.
+// The dates are taken in the location or the location of given time if it's nil.
+type intervalSchedule struct {
+	inner    cron.Schedule
+	interval interval
+	loc      *time.Location
+}
+
+// Next returns the next activation time, later than the given time.
+// If no time can be found within five years, it returns the zero time like cron.SpecSchedule.
+func (s intervalSchedule) Next(t time.Time) time.Time {
+	loc := s.loc
+	if loc == nil {
+		loc = t.Location()
+	}
+
+	unit, limit := s.interval.unitDays(), t.Add(maxSetSearch)
+	for curr := t; ; {
+		next := s.inner.Next(curr)
+		if next.IsZero() || next.After(limit) {
+			return time.Time{}
+		}
+		idx := floorDiv(daysSince(s.interval.anchor, next.In(loc)), unit)
+		if idx < 0 {
+			// the intervals start from the anchor date, so jump to it
+			curr = time.Date(s.interval.anchor.Year(), s.interval.anchor.Month(), s.interval.anchor.Day(), 0, 0, 0, 0, loc).Add(-time.Nanosecond)
+			continue
+		}
+		if skip := idx % s.interval.count; skip == 0 {
+			return next
+		}
+
+		// jump to the beginning of the next matching unit
+		start := s.interval.anchor.AddDate(0, 0, (idx/s.interval.count+1)*s.interval.count*unit)
+		curr = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, loc).Add(-time.Nanosecond)
+	}
+}
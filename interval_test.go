@@ -0,0 +1,99 @@
+package cronrange
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseInterval(t *testing.T) {
+	tests := []struct {
+		name      string
+		s         string
+		wantCount int
+		wantWeeks bool
+		wantErr   bool
+	}{
+		{"Empty", "", 0, false, true},
+		{"Missing unit", "3", 0, false, true},
+		{"Unknown unit", "3h", 0, false, true},
+		{"Missing number", "w", 0, false, true},
+		{"Zero", "0d", 0, false, true},
+		{"Negative", "-2w", 0, false, true},
+		{"Fraction", "1.5w", 0, false, true},
+		{"Days", "3d", 3, false, false},
+		{"Weeks", "2w", 2, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotCount, gotWeeks, err := parseInterval(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseInterval() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && (gotCount != tt.wantCount || gotWeeks != tt.wantWeeks) {
+				t.Errorf("parseInterval() got = %v, %v, want %v, %v", gotCount, gotWeeks, tt.wantCount, tt.wantWeeks)
+			}
+			if !tt.wantErr && formatInterval(gotCount, gotWeeks) != tt.s {
+				t.Errorf("formatInterval() got = %v, want %v", formatInterval(gotCount, gotWeeks), tt.s)
+			}
+		})
+	}
+}
+
+func TestFloorDiv(t *testing.T) {
+	for _, tt := range []struct{ a, b, want int }{
+		{7, 7, 1}, {6, 7, 0}, {0, 7, 0}, {-1, 7, -1}, {-7, 7, -1}, {-8, 7, -2},
+	} {
+		if got := floorDiv(tt.a, tt.b); got != tt.want {
+			t.Errorf("floorDiv(%d, %d) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestIntervalSchedule_Next(t *testing.T) {
+	anchor := time.Date(2025, 1, 7, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		expr  string
+		count int
+		weeks bool
+		t     time.Time
+		want  time.Time
+	}{
+		{"Every other Tuesday at anchor", "0 9 * * 2", 2, true, parseTime(locationUTC, "2025-01-07 00:00:00"), parseTime(locationUTC, "2025-01-07 09:00:00")},
+		{"Every other Tuesday skipped", "0 9 * * 2", 2, true, parseTime(locationUTC, "2025-01-07 09:00:00"), parseTime(locationUTC, "2025-01-21 09:00:00")},
+		{"Every other Tuesday before anchor", "0 9 * * 2", 2, true, parseTime(locationUTC, "2024-12-20 00:00:00"), parseTime(locationUTC, "2025-01-07 09:00:00")},
+		{"Every other Tuesday skipped before anchor", "0 9 * * 2", 2, true, parseTime(locationUTC, "2024-12-25 00:00:00"), parseTime(locationUTC, "2025-01-07 09:00:00")},
+		{"Every other Tuesday long before anchor", "0 9 * * 2", 2, true, parseTime(locationUTC, "2024-12-01 00:00:00"), parseTime(locationUTC, "2025-01-07 09:00:00")},
+		{"Every other Tuesday in Tokyo before anchor", "0 9 * * 2", 2, true, parseTime(locationTokyo, "2024-12-25 00:00:00"), parseTime(locationTokyo, "2025-01-07 09:00:00")},
+		{"Every other week on weekdays", "0 9 * * 1-5", 2, true, parseTime(locationUTC, "2025-01-10 12:00:00"), parseTime(locationUTC, "2025-01-13 09:00:00")},
+		{"Every other week on weekdays skipped", "0 9 * * 1-5", 2, true, parseTime(locationUTC, "2025-01-13 12:00:00"), parseTime(locationUTC, "2025-01-21 09:00:00")},
+		{"Every 3 days", "0 12 * * *", 3, false, parseTime(locationUTC, "2025-01-07 12:00:00"), parseTime(locationUTC, "2025-01-10 12:00:00")},
+		{"Every 3 days before anchor", "0 12 * * *", 3, false, parseTime(locationUTC, "2025-01-01 00:00:00"), parseTime(locationUTC, "2025-01-07 12:00:00")},
+		{"Every 3 days in Tokyo", "0 12 * * *", 3, false, parseTime(locationTokyo, "2025-01-07 12:00:00"), parseTime(locationTokyo, "2025-01-10 12:00:00")},
+		{"Never matches", "0 0 8 1 * 2025", 2, false, parseTime(locationUTC, "2025-01-01 00:00:00"), zeroTime},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inner, err := parseSchedule(tt.expr, emptyString, false)
+			if err != nil {
+				t.Errorf("parseSchedule() error = %v", err)
+				return
+			}
+			s := intervalSchedule{inner: inner, interval: interval{count: tt.count, weeks: tt.weeks, anchor: anchor}}
+			if got := s.Next(tt.t); !got.Equal(tt.want) {
+				t.Errorf("Next() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func BenchmarkIntervalSchedule_Next(b *testing.B) {
+	inner, _ := parseSchedule("0 9 * * 1-5", timeZoneUTC, false)
+	s := intervalSchedule{inner: inner, interval: interval{count: 2, weeks: true, anchor: time.Date(2025, 1, 7, 0, 0, 0, 0, time.UTC)}}
+	t := parseTime(locationUTC, "2025-01-13 12:00:00")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = s.Next(t)
+	}
+}
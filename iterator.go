@@ -0,0 +1,121 @@
+package cronrange
+
+import (
+	"time"
+)
+
+// OccurrenceIterator walks through the occurrence time ranges of a CronRange lazily, forward with Next() and backward with Prev().
+// It works like a cursor between the time ranges, so calling Prev() right after Next() returns the same time range again.
+//
+// The validity bounds and exceptions are applied in the same way as NextOccurrences(), and the time ranges split by an exception are returned one by one.
+type OccurrenceIterator struct {
+	cr *CronRange
+	// at is the activation time of the time ranges around the cursor, or the given time before any move
+	at time.Time
+	// parts are the time ranges of the activation
+	parts []TimeRange
+	// pos is the number of parts before the cursor
+	pos int
+}
+
+// Iterator returns an OccurrenceIterator with the cursor at the given time,
+// so Next() starts from the time range later than it, and Prev() starts from the one earlier than it.
+//
+// It panics if the CronRange instance is nil or incomplete.
+func (cr *CronRange) Iterator(t time.Time) *OccurrenceIterator {
+	cr.checkPrecondition()
+	return &OccurrenceIterator{cr: cr, at: t}
+}
+
+// Next returns the next time range and moves the cursor after it, or false if there's none within next five years.
+// The cursor stays if there's none.
+func (it *OccurrenceIterator) Next() (tr TimeRange, ok bool) {
+	if it.pos == len(it.parts) && !it.advance() {
+		return
+	}
+	tr = it.parts[it.pos]
+	it.pos++
+	return tr, true
+}
+
+// Prev returns the previous time range and moves the cursor before it, or false if there's none within previous five years.
+// The cursor stays if there's none.
+func (it *OccurrenceIterator) Prev() (tr TimeRange, ok bool) {
+	if it.pos == 0 && !it.retreat() {
+		return
+	}
+	it.pos--
+	return it.parts[it.pos], true
+}
+
+// advance moves to the time ranges of the next activation having any, with the cursor before them.
+func (it *OccurrenceIterator) advance() bool {
+	cr, curr := it.cr, it.at
+	if !cr.notBefore.IsZero() && curr.Before(cr.notBefore) {
+		// jump to the time range running across the not-before bound if it exists
+		if start, found := lastActivation(cr.schedule, cr.notBefore, cr.maxLength()+1*time.Second); found {
+			if start.After(curr) {
+				curr = start.Add(-time.Nanosecond)
+			}
+		} else if skip := cr.notBefore.Add(-time.Nanosecond); skip.After(curr) {
+			curr = skip
+		}
+	}
+
+	for origin := curr; ; {
+		next := cr.schedule.Next(curr)
+		if next.Before(curr) || (!cr.notAfter.IsZero() && next.After(cr.notAfter)) {
+			return false
+		}
+		if len(cr.exceptionRanges()) > 0 && next.Sub(origin) > maxSetSearch {
+			// stop searching if the exceptions drop all the time ranges
+			return false
+		}
+		curr = next
+
+		parts, ok := cr.occurrencesAt(next)
+		if !ok {
+			// no ending time is found by the end expression
+			return false
+		}
+		if len(parts) > 0 {
+			it.at, it.parts, it.pos = next, parts, 0
+			return true
+		}
+	}
+}
+
+// retreat moves to the time ranges of the previous activation having any, with the cursor after them.
+func (it *OccurrenceIterator) retreat() bool {
+	cr, curr := it.cr, it.at
+	if !cr.notAfter.IsZero() && curr.After(cr.notAfter) {
+		// start from the time range starting at the not-after bound if it exists
+		curr = cr.notAfter.Add(time.Nanosecond)
+	}
+
+	for origin := curr; ; {
+		prev := prevActivation(cr.schedule, curr)
+		if prev.IsZero() || !prev.Before(curr) {
+			return false
+		}
+		if len(cr.exceptionRanges()) > 0 && origin.Sub(prev) > maxSetSearch {
+			// stop searching if the exceptions drop all the time ranges
+			return false
+		}
+		curr = prev
+
+		if !cr.notBefore.IsZero() && !cr.endOf(prev).After(cr.notBefore) {
+			// the earlier ones end before the not-before bound too
+			return false
+		}
+		parts, ok := cr.occurrencesAt(prev)
+		if !ok {
+			// no ending time is found by the end expression
+			return false
+		}
+		if len(parts) > 0 {
+			it.at, it.parts, it.pos = prev, parts, len(parts)
+			return true
+		}
+	}
+}
@@ -0,0 +1,25 @@
+//go:build go1.23
+
+package cronrange
+
+import (
+	"iter"
+	"time"
+)
+
+// Occurrences returns a sequence of the occurrence time ranges later than the given time in chronological order,
+// which are found lazily by an OccurrenceIterator, so the range loop over it can stop at any time.
+// Each range loop over the sequence starts from the given time again.
+//
+// It panics if the CronRange instance is nil or incomplete.
+func (cr *CronRange) Occurrences(from time.Time) iter.Seq[TimeRange] {
+	cr.checkPrecondition()
+	return func(yield func(TimeRange) bool) {
+		it := cr.Iterator(from)
+		for tr, ok := it.Next(); ok; tr, ok = it.Next() {
+			if !yield(tr) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,53 @@
+//go:build go1.23
+
+package cronrange
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCronRange_Occurrences(t *testing.T) {
+	tests := []struct {
+		name  string
+		cr    *CronRange
+		from  time.Time
+		until time.Time
+	}{
+		{"Every 10 minutes in Bangkok", crEvery10MinBangkok, firstSec2019Local, firstSec2019Local.Add(2 * time.Hour)},
+		{"Split by exception", crMustParse("DR=480; TZ=Etc/UTC; EXCEPT=[DR=60; TZ=Etc/UTC; 0 12 * * *]; 0 9 * * *"), firstSec2020Utc, firstSec2020Utc.Add(72 * time.Hour)},
+		{"Limited by count", crMustParse("DR=15; TZ=Etc/UTC; CNT=3; START=2025-01-06T09:00:00Z; 0 9 * * 1"), firstSec2020Utc, parseTime(locationUTC, "2030-01-01 00:00:00")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []TimeRange
+			for occur := range tt.cr.Occurrences(tt.from) {
+				if !occur.Start.Before(tt.until) {
+					break
+				}
+				got = append(got, occur)
+			}
+			var want []TimeRange
+			for _, occur := range tt.cr.OccurrencesBetween(tt.from, tt.until) {
+				// the ones running across the given time are not later than it
+				if occur.Start.After(tt.from) {
+					want = append(want, occur)
+				}
+			}
+			if !isTimeRangeSliceEqual(got, want) {
+				t.Errorf("Occurrences() got = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func BenchmarkCronRange_Occurrences(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		n := 0
+		for range crEvery10MinBangkok.Occurrences(firstSec2019Local) {
+			if n++; n == 10 {
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package cronrange
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOccurrenceIterator_Next(t *testing.T) {
+	tests := []struct {
+		name  string
+		cr    *CronRange
+		t     time.Time
+		count int
+	}{
+		{"Every New Year's Day in Tokyo", crEveryNewYearsDayTokyo, firstSec2018Tokyo.Add(-1 * time.Second), 3},
+		{"Very complicated time periods", crVeryComplicated, firstSec2017Honolulu, 20},
+		{"Not before bound", crMustParse("DR=120; TZ=Etc/UTC; NB=2020-01-02T01:00:00Z; NA=2020-01-04T00:30:00Z; 0 0 * * *"), firstSec2020Utc.AddDate(-1, 0, 0), 5},
+		{"Split by exceptions", crMustParse("DR=480; TZ=Etc/UTC; EXCEPT=[DR=60; TZ=Etc/UTC; 0 12 * * *]; EXCEPT=[DR=30; TZ=Etc/UTC; 0 16 * * *]; 0 9 * * *"), firstSec2020Utc, 8},
+		{"Limited by count", crMustParse("DR=15; TZ=Etc/UTC; CNT=3; START=2025-01-06T09:00:00Z; 0 9 * * 1"), firstSec2020Utc, 5},
+		{"End expression", crMustParse("END=0 6 * * 1; TZ=Europe/Berlin; 0 18 * * 5"), firstSec2020Utc, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []TimeRange
+			it := tt.cr.Iterator(tt.t)
+			for i := 0; i < tt.count; i++ {
+				occur, ok := it.Next()
+				if !ok {
+					break
+				}
+				got = append(got, occur)
+			}
+			if want := tt.cr.NextOccurrences(tt.t, tt.count); !isTimeRangeSliceEqual(got, want) {
+				t.Errorf("Next() got = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestOccurrenceIterator_Prev(t *testing.T) {
+	tests := []struct {
+		name  string
+		cr    *CronRange
+		t     time.Time
+		count int
+	}{
+		{"Every New Year's Day in Tokyo", crEveryNewYearsDayTokyo, firstSec2018Tokyo, 3},
+		{"Very complicated time periods", crVeryComplicated, firstSec2017Honolulu, 20},
+		{"Validity bounds", crMustParse("DR=120; TZ=Etc/UTC; NB=2020-01-02T01:00:00Z; NA=2020-01-04T00:30:00Z; 0 0 * * *"), firstSec2020Utc.AddDate(1, 0, 0), 5},
+		{"Split by exceptions", crMustParse("DR=480; TZ=Etc/UTC; EXCEPT=[DR=60; TZ=Etc/UTC; 0 12 * * *]; EXCEPT=[DR=30; TZ=Etc/UTC; 0 16 * * *]; 0 9 * * *"), firstSec2020Utc, 8},
+		{"Limited by count", crMustParse("DR=15; TZ=Etc/UTC; CNT=3; START=2025-01-06T09:00:00Z; 0 9 * * 1"), parseTime(locationUTC, "2025-03-01 00:00:00"), 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []TimeRange
+			it := tt.cr.Iterator(tt.t)
+			for i := 0; i < tt.count; i++ {
+				occur, ok := it.Prev()
+				if !ok {
+					break
+				}
+				got = append(got, occur)
+			}
+			if want := tt.cr.PreviousOccurrences(tt.t, tt.count); !isTimeRangeSliceEqual(got, want) {
+				t.Errorf("Prev() got = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestOccurrenceIterator_BackAndForth(t *testing.T) {
+	cr := crMustParse("DR=480; TZ=Etc/UTC; EXCEPT=[DR=60; TZ=Etc/UTC; 0 12 * * *]; 0 9 * * *")
+	it := cr.Iterator(firstSec2020Utc)
+
+	var forward []TimeRange
+	for i := 0; i < 5; i++ {
+		occur, _ := it.Next()
+		forward = append(forward, occur)
+	}
+	for i := len(forward) - 1; i >= 0; i-- {
+		if occur, ok := it.Prev(); !ok || occur != forward[i] {
+			t.Errorf("Prev() got = %v, %v, want %v", occur, ok, forward[i])
+		}
+	}
+	if occur, ok := it.Next(); !ok || occur != forward[0] {
+		t.Errorf("Next() got = %v, %v, want %v", occur, ok, forward[0])
+	}
+
+	// the cursor stays if there's none
+	bounded := crMustParse("DR=60; TZ=Etc/UTC; NB=2020-01-01T00:00:00Z; NA=2020-01-02T00:00:00Z; 0 0 * * *")
+	it = bounded.Iterator(firstSec2020Utc.Add(-time.Second))
+	first, _ := it.Next()
+	second, _ := it.Next()
+	if _, ok := it.Next(); ok {
+		t.Errorf("Next() got ok out of bounds")
+	}
+	if occur, ok := it.Prev(); !ok || occur != second {
+		t.Errorf("Prev() got = %v, %v, want %v", occur, ok, second)
+	}
+	if occur, ok := it.Prev(); !ok || occur != first {
+		t.Errorf("Prev() got = %v, %v, want %v", occur, ok, first)
+	}
+	if _, ok := it.Prev(); ok {
+		t.Errorf("Prev() got ok out of bounds")
+	}
+}
+
+func TestCronRange_Iterator(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Iterator() got no panic for nil CronRange")
+		}
+	}()
+	crNil.Iterator(firstSec2020Utc)
+}
+
+func BenchmarkOccurrenceIterator_Next(b *testing.B) {
+	it := crEvery10MinBangkok.Iterator(firstSec2019Local)
+	for i := 0; i < b.N; i++ {
+		_, _ = it.Next()
+	}
+}
@@ -0,0 +1,365 @@
+package cronrange
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+var (
+	dowNames = map[string]int{
+		"sun": 0,
+		"mon": 1,
+		"tue": 2,
+		"wed": 3,
+		"thu": 4,
+		"fri": 5,
+		"sat": 6,
+	}
+)
+
+// dayRule matches days with Quartz-style operators in day-of-month and day-of-week fields,
+// along with the plain values in the same fields which are stored as bit sets like cron.SpecSchedule.
+type dayRule struct {
+	domBits, dowBits uint64
+	domStar, dowStar bool
+	domOps, dowOps   []dayOperator
+}
+
+// dayOperator checks if the given day matches a Quartz-style operator.
+type dayOperator func(year int, month time.Month, day int) bool
+
+// daysIn returns the number of days in the given month.
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// weekdayOf returns the day of week of the given day.
+func weekdayOf(year int, month time.Month, day int) time.Weekday {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Weekday()
+}
+
+// parseDomOperator parses Quartz-style operators in the day-of-month field:
+//
+//	L	the last day of the month
+//	L-3	the third to last day of the month
+//	LW	the last weekday (Monday to Friday) of the month
+//	15W	the weekday nearest to the 15th of the month, without crossing the boundary of the month
+//
+// It returns nil if the item is not an operator.
+func parseDomOperator(item string) (op dayOperator, err error) {
+	item = strings.ToUpper(item)
+	switch {
+	case item == "L":
+		op = func(year int, month time.Month, day int) bool {
+			return day == daysIn(year, month)
+		}
+	case item == "LW":
+		op = func(year int, month time.Month, day int) bool {
+			last := daysIn(year, month)
+			switch weekdayOf(year, month, last) {
+			case time.Saturday:
+				last--
+			case time.Sunday:
+				last -= 2
+			}
+			return day == last
+		}
+	case strings.HasPrefix(item, "L-"):
+		var offset int
+		if offset, err = strconv.Atoi(item[2:]); err != nil || offset < 0 || offset > 30 {
+			err = fmt.Errorf("invalid offset of last day: %q", item)
+			return
+		}
+		op = func(year int, month time.Month, day int) bool {
+			return day == daysIn(year, month)-offset
+		}
+	case strings.HasSuffix(item, "W"):
+		var target int
+		if target, err = strconv.Atoi(item[:len(item)-1]); err != nil || target < 1 || target > 31 {
+			err = fmt.Errorf("invalid day of nearest weekday: %q", item)
+			return
+		}
+		op = func(year int, month time.Month, day int) bool {
+			last := daysIn(year, month)
+			if target > last {
+				return false
+			}
+			nearest := target
+			switch weekdayOf(year, month, target) {
+			case time.Saturday:
+				if nearest--; nearest < 1 {
+					nearest = target + 2
+				}
+			case time.Sunday:
+				if nearest++; nearest > last {
+					nearest = target - 2
+				}
+			}
+			return day == nearest
+		}
+	}
+	return
+}
+
+// parseDowOperator parses Quartz-style operators in the day-of-week field:
+//
+//	5L	the last Friday of the month
+//	5#3	the third Friday of the month
+//
+// Days of week are either numbers from 0 (Sunday) to 7 (Sunday again) or names like FRI. It returns nil if the item is not an operator.
+func parseDowOperator(item string) (op dayOperator, err error) {
+	var (
+		dowStr string
+		nth    int
+		last   bool
+	)
+	switch {
+	case strings.Contains(item, "#"):
+		idx := strings.Index(item, "#")
+		dowStr = item[:idx]
+		if nth, err = strconv.Atoi(item[idx+1:]); err != nil || nth < 1 || nth > 5 {
+			err = fmt.Errorf("invalid nth day of week: %q", item)
+			return
+		}
+	case len(item) > 1 && strings.HasSuffix(strings.ToUpper(item), "L"):
+		dowStr, last = item[:len(item)-1], true
+	default:
+		return
+	}
+
+	var dow int
+	if n, ok := dowNames[strings.ToLower(dowStr)]; ok {
+		dow = n
+	} else if dow, err = strconv.Atoi(dowStr); err != nil || dow < 0 || dow > 7 {
+		err = fmt.Errorf("invalid day of week: %q", item)
+		return
+	}
+	weekday := time.Weekday(dow % 7)
+
+	if last {
+		op = func(year int, month time.Month, day int) bool {
+			return weekdayOf(year, month, day) == weekday && day+7 > daysIn(year, month)
+		}
+	} else {
+		op = func(year int, month time.Month, day int) bool {
+			return weekdayOf(year, month, day) == weekday && (day-1)/7+1 == nth
+		}
+	}
+	return
+}
+
+// parseDayRule splits the day-of-month and day-of-week fields into Quartz-style operators and plain values,
+// it returns nil if there's no operator in both fields.
+func parseDayRule(domField, dowField string) (rule *dayRule, err error) {
+	var domPlains, dowPlains []string
+	rule = &dayRule{}
+	for _, item := range strings.Split(domField, ",") {
+		var op dayOperator
+		if op, err = parseDomOperator(item); err != nil {
+			return nil, err
+		} else if op != nil {
+			rule.domOps = append(rule.domOps, op)
+		} else {
+			domPlains = append(domPlains, item)
+		}
+	}
+	for _, item := range strings.Split(dowField, ",") {
+		var op dayOperator
+		if op, err = parseDowOperator(item); err != nil {
+			return nil, err
+		} else if op != nil {
+			rule.dowOps = append(rule.dowOps, op)
+		} else {
+			dowPlains = append(dowPlains, item)
+		}
+	}
+	if len(rule.domOps) == 0 && len(rule.dowOps) == 0 {
+		return nil, nil
+	}
+
+	// Leverage the standard parser for the plain values
+	if len(domPlains) > 0 || len(dowPlains) > 0 {
+		domExpr, dowExpr := strings.Join(domPlains, ","), strings.Join(dowPlains, ",")
+		if domExpr == "" {
+			domExpr = "1"
+		}
+		if dowExpr == "" {
+			dowExpr = "0"
+		}
+		var sched cron.Schedule
+		if sched, err = cronParser.Parse(fmt.Sprintf("0 0 %s * %s", domExpr, dowExpr)); err != nil {
+			return nil, err
+		}
+		spec := sched.(*cron.SpecSchedule)
+		if len(domPlains) > 0 {
+			rule.domBits, rule.domStar = spec.Dom, spec.Dom&starBit > 0
+		}
+		if len(dowPlains) > 0 {
+			rule.dowBits, rule.dowStar = spec.Dow, spec.Dow&starBit > 0
+		}
+	}
+	return
+}
+
+// matches checks if the given day satisfies the rule, following the convention of cron:
+// if either field is unrestricted, i.e. starts with '*' or '?', both fields must match, otherwise either one does.
+func (r *dayRule) matches(year int, month time.Month, day int) bool {
+	domMatch := 1<<uint(day)&r.domBits > 0
+	for _, op := range r.domOps {
+		if domMatch {
+			break
+		}
+		domMatch = op(year, month, day)
+	}
+	dowMatch := 1<<uint(weekdayOf(year, month, day))&r.dowBits > 0
+	for _, op := range r.dowOps {
+		if dowMatch {
+			break
+		}
+		dowMatch = op(year, month, day)
+	}
+	if r.domStar || r.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// starBit is set in the bit sets of cron.SpecSchedule if a star was included in the field.
+const starBit = 1 << 63
+
+// quartzSchedule wraps a cron.SpecSchedule whose day fields are unrestricted, and filters its activations by the day rule.
+type quartzSchedule struct {
+	spec *cron.SpecSchedule
+	rule *dayRule
+}
+
+// Next returns the next activation time, later than the given time.
+// If no time can be found within five years, it returns the zero time like cron.SpecSchedule.
+func (s quartzSchedule) Next(t time.Time) time.Time {
+	yearLimit := t.Year() + 5
+	for curr := t; ; {
+		next := s.spec.Next(curr)
+		if next.IsZero() || next.Year() > yearLimit {
+			return time.Time{}
+		}
+
+		local := next
+		if s.spec.Location != time.Local {
+			local = next.In(s.spec.Location)
+		}
+		year, month, day := local.Date()
+		if s.rule.matches(year, month, day) {
+			return next
+		}
+
+		// skip to the end of the day
+		curr = time.Date(year, month, day+1, 0, 0, 0, 0, local.Location()).Add(-time.Nanosecond)
+	}
+}
+
+const (
+	minYear = 1970
+	maxYear = 2099
+)
+
+// parseYearField parses the Quartz-style year field, which consists of comma-separated items like "2025", "2025-2027", "*/4" or "2020-2040/4",
+// the years should be within 1970-2099. It returns the sorted years, or nil if the field is unrestricted.
+func parseYearField(field string) (years []int, err error) {
+	if field == "*" || field == "?" {
+		return nil, nil
+	}
+
+	var matched [maxYear - minYear + 1]bool
+	for _, item := range strings.Split(field, ",") {
+		var (
+			rangeExpr = item
+			step      = 1
+			low, high int
+		)
+		if idx := strings.Index(item, "/"); idx >= 0 {
+			rangeExpr = item[:idx]
+			if step, err = strconv.Atoi(item[idx+1:]); err != nil || step <= 0 {
+				return nil, fmt.Errorf("invalid step of year: %q", item)
+			}
+		}
+
+		switch lowHigh := strings.SplitN(rangeExpr, "-", 2); {
+		case rangeExpr == "*":
+			low, high = minYear, maxYear
+		case len(lowHigh) == 2:
+			low, err = strconv.Atoi(lowHigh[0])
+			if err == nil {
+				high, err = strconv.Atoi(lowHigh[1])
+			}
+		default:
+			if low, err = strconv.Atoi(rangeExpr); err == nil {
+				high = low
+				if step > 1 {
+					high = maxYear
+				}
+			}
+		}
+		if err != nil || low < minYear || high > maxYear || low > high {
+			return nil, fmt.Errorf("invalid year: %q", item)
+		}
+
+		for y := low; y <= high; y += step {
+			matched[y-minYear] = true
+		}
+	}
+
+	for i, ok := range matched {
+		if ok {
+			years = append(years, minYear+i)
+		}
+	}
+	return
+}
+
+// yearSchedule filters the activations of the inner schedule by the years, in the location or the location of given time if it's nil.
+type yearSchedule struct {
+	inner cron.Schedule
+	years []int
+	loc   *time.Location
+}
+
+// Next returns the next activation time, later than the given time. It returns the zero time if the years run out.
+func (s yearSchedule) Next(t time.Time) time.Time {
+	loc := s.loc
+	if loc == nil {
+		loc = t.Location()
+	}
+
+	for curr := t; ; {
+		// jump to the beginning of the next matching year if necessary
+		year := curr.In(loc).Year()
+		idx := sort.SearchInts(s.years, year)
+		if idx == len(s.years) {
+			return time.Time{}
+		} else if s.years[idx] != year {
+			curr = time.Date(s.years[idx], time.January, 1, 0, 0, 0, 0, loc).Add(-time.Nanosecond)
+			year = s.years[idx]
+		}
+
+		next := s.inner.Next(curr)
+		if next.IsZero() {
+			// nothing found by the inner schedule, try the next year
+			curr = time.Date(year+1, time.January, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if s.hasYear(next.In(loc).Year()) {
+			return next
+		}
+		curr = next
+	}
+}
+
+func (s yearSchedule) hasYear(year int) bool {
+	idx := sort.SearchInts(s.years, year)
+	return idx < len(s.years) && s.years[idx] == year
+}
@@ -0,0 +1,217 @@
+package cronrange
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDayRule(t *testing.T) {
+	tests := []struct {
+		name     string
+		domField string
+		dowField string
+		wantRule bool
+		wantErr  bool
+	}{
+		{"Plain fields", "1-15", "*", false, false},
+		{"Star fields", "*", "?", false, false},
+		{"Last day", "L", "*", true, false},
+		{"Last day with offset", "L-3", "*", true, false},
+		{"Last weekday", "LW", "*", true, false},
+		{"Nearest weekday", "15W", "*", true, false},
+		{"Last Friday", "*", "5L", true, false},
+		{"Last Friday by name", "?", "FRIL", true, false},
+		{"Third Friday", "*", "5#3", true, false},
+		{"Third Friday by name", "*", "fri#3", true, false},
+		{"Mixed with plain values", "1,L", "1-5,6#1", true, false},
+		{"Invalid last day offset", "L-31", "*", false, true},
+		{"Invalid nearest weekday", "32W", "*", false, true},
+		{"Invalid weekday only", "W", "*", false, true},
+		{"Invalid nth", "*", "5#6", false, true},
+		{"Invalid day of week", "*", "8#1", false, true},
+		{"Invalid last day of week", "*", "XL", false, true},
+		{"Invalid plain values", "1-40,L", "*", false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotRule, err := parseDayRule(tt.domField, tt.dowField)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseDayRule() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if (gotRule != nil) != tt.wantRule {
+				t.Errorf("parseDayRule() gotRule = %v, wantRule %v", gotRule, tt.wantRule)
+			}
+		})
+	}
+}
+
+func TestDayRule_Matches(t *testing.T) {
+	tests := []struct {
+		name     string
+		domField string
+		dowField string
+		days     []string
+	}{
+		{"Last day in 2020", "L", "*", []string{"2020-01-31", "2020-02-29", "2020-03-31", "2020-04-30"}},
+		{"Third to last day in 2019", "L-2", "*", []string{"2019-01-29", "2019-02-26", "2019-03-29", "2019-04-28"}},
+		{"Last weekday in 2019", "LW", "*", []string{"2019-01-31", "2019-02-28", "2019-03-29", "2019-04-30", "2019-05-31", "2019-06-28"}},
+		{"Nearest weekday to 15th in 2019", "15W", "*", []string{"2019-01-15", "2019-02-15", "2019-03-15", "2019-04-15", "2019-05-15", "2019-06-14", "2019-07-15", "2019-08-15", "2019-09-16"}},
+		{"Nearest weekday to 1st in 2019", "1W", "*", []string{"2019-01-01", "2019-02-01", "2019-03-01", "2019-04-01", "2019-05-01", "2019-06-03", "2019-07-01"}},
+		{"Nearest weekday to 31st in 2019", "31W", "*", []string{"2019-01-31", "2019-03-29", "2019-05-31", "2019-07-31", "2019-08-30"}},
+		{"Last Friday in 2019", "*", "5L", []string{"2019-01-25", "2019-02-22", "2019-03-29", "2019-04-26"}},
+		{"Third Friday in 2019", "?", "FRI#3", []string{"2019-01-18", "2019-02-15", "2019-03-15", "2019-04-19"}},
+		{"Fifth Sunday in 2019", "*", "7#5", []string{"2019-03-31", "2019-06-30", "2019-09-29", "2019-12-29"}},
+		{"First day or last Sunday in early 2019", "1", "0L", []string{"2019-01-01", "2019-01-27", "2019-02-01", "2019-02-24", "2019-03-01", "2019-03-31"}},
+		{"Last day or Tuesday in 2019", "L", "2", []string{"2019-04-30", "2019-05-07", "2019-05-14", "2019-05-21", "2019-05-28", "2019-05-31", "2019-06-04"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := parseDayRule(tt.domField, tt.dowField)
+			if err != nil || rule == nil {
+				t.Errorf("parseDayRule() rule = %v, error = %v", rule, err)
+				return
+			}
+
+			first, _ := time.Parse("2006-01-02", tt.days[0])
+			last, _ := time.Parse("2006-01-02", tt.days[len(tt.days)-1])
+			var got []string
+			for d := first; !d.After(last); d = d.AddDate(0, 0, 1) {
+				if rule.matches(d.Year(), d.Month(), d.Day()) {
+					got = append(got, d.Format("2006-01-02"))
+				}
+			}
+			if len(got) != len(tt.days) {
+				t.Errorf("matches() got = %v, want %v", got, tt.days)
+				return
+			}
+			for i := range got {
+				if got[i] != tt.days[i] {
+					t.Errorf("matches() got = %v, want %v", got, tt.days)
+					return
+				}
+			}
+		})
+	}
+}
+
+func TestQuartzSchedule_Next(t *testing.T) {
+	tests := []struct {
+		name     string
+		expr     string
+		timeZone string
+		t        time.Time
+		want     time.Time
+	}{
+		{"Last day at noon", "0 12 L * *", emptyString, parseLocalTime("2020-02-01 00:00:00"), parseLocalTime("2020-02-29 12:00:00")},
+		{"Last day at noon on the day", "0 12 L * *", emptyString, parseLocalTime("2020-02-29 11:00:00"), parseLocalTime("2020-02-29 12:00:00")},
+		{"Last day at noon after the time", "0 12 L * *", emptyString, parseLocalTime("2020-02-29 12:00:00"), parseLocalTime("2020-03-31 12:00:00")},
+		{"Last Friday in Tokyo", "0 9 * * 5L", timeZoneTokyo, firstSec2018Tokyo, parseTime(locationTokyo, "2018-01-26 09:00:00")},
+		{"Last Friday in Tokyo (UTC view)", "0 9 * * 5L", timeZoneTokyo, parseTime(locationUTC, "2018-01-26 00:00:00"), parseTime(locationUTC, "2018-02-23 00:00:00")},
+		{"Nearest weekday of Christmas", "0 8 25W 12 *", timeZoneNewYork, firstSec2020Utc, parseTime(locationUTC, "2020-12-25 13:00:00")},
+		{"Nearest weekday of Christmas on Sunday", "0 8 25W 12 *", timeZoneNewYork, parseTime(locationUTC, "2022-01-01 00:00:00"), parseTime(locationUTC, "2022-12-26 13:00:00")},
+		{"Never exists", "0 0 30W 2 *", emptyString, firstSec2020Utc, zeroTime},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sched, err := parseCronSchedule(tt.expr, tt.timeZone, false)
+			if err != nil {
+				t.Errorf("parseCronSchedule() error = %v", err)
+				return
+			}
+			if _, ok := sched.(quartzSchedule); !ok {
+				t.Errorf("parseCronSchedule() got %T, want quartzSchedule", sched)
+				return
+			}
+			if got := sched.Next(tt.t); !got.Equal(tt.want) {
+				t.Errorf("Next() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func BenchmarkQuartzSchedule_Next(b *testing.B) {
+	sched, _ := parseCronSchedule("0 9 * * 5L", timeZoneTokyo, false)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = sched.Next(firstSec2018Tokyo)
+	}
+}
+
+func TestParseYearField(t *testing.T) {
+	tests := []struct {
+		name    string
+		field   string
+		want    []int
+		wantErr bool
+	}{
+		{"Star", "*", nil, false},
+		{"Question mark", "?", nil, false},
+		{"Single year", "2025", []int{2025}, false},
+		{"Year range", "2025-2027", []int{2025, 2026, 2027}, false},
+		{"Year list", "2027,2025", []int{2025, 2027}, false},
+		{"Year range with step", "2020-2030/4", []int{2020, 2024, 2028}, false},
+		{"Year with step", "2090/4", []int{2090, 2094, 2098}, false},
+		{"Star with step", "*/50", []int{1970, 2020, 2070}, false},
+		{"Before minimum", "1969", nil, true},
+		{"After maximum", "2100", nil, true},
+		{"Reversed range", "2027-2025", nil, true},
+		{"Zero step", "2025/0", nil, true},
+		{"Not a number", "this year", nil, true},
+		{"Empty item", "2025,", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseYearField(tt.field)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseYearField() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(got) != len(tt.want) {
+				t.Errorf("parseYearField() got = %v, want %v", got, tt.want)
+				return
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("parseYearField() got = %v, want %v", got, tt.want)
+					return
+				}
+			}
+		})
+	}
+}
+
+func TestYearSchedule_Next(t *testing.T) {
+	tests := []struct {
+		name     string
+		expr     string
+		timeZone string
+		t        time.Time
+		want     time.Time
+	}{
+		{"Before the years", "0 0 28 11 * 2025-2027", emptyString, firstSec2020Utc, parseTime(locationUTC, "2025-11-28 00:00:00")},
+		{"Within the years", "0 0 28 11 * 2025-2027", emptyString, parseTime(locationUTC, "2025-11-28 00:00:00"), parseTime(locationUTC, "2026-11-28 00:00:00")},
+		{"After the years", "0 0 28 11 * 2025-2027", emptyString, parseTime(locationUTC, "2027-11-28 00:00:00"), zeroTime},
+		{"Far away year", "0 0 1 1 * 2090", emptyString, firstSec2020Utc, parseTime(locationUTC, "2090-01-01 00:00:00")},
+		{"Gap between years", "0 0 1 1 * 2020,2030", emptyString, firstSec2020Utc, parseTime(locationUTC, "2030-01-01 00:00:00")},
+		{"Year boundary in Tokyo", "0 0 1 1 * 2019", timeZoneTokyo, parseTime(locationUTC, "2018-12-31 12:00:00"), parseTime(locationUTC, "2018-12-31 15:00:00")},
+		{"Never exists in the years", "0 0 29 2 * 2021-2023", emptyString, firstSec2020Utc, zeroTime},
+		{"Last Friday in the year", "0 9 * * 5L 2025", timeZoneTokyo, firstSec2020Utc, parseTime(locationTokyo, "2025-01-31 09:00:00")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sched, err := parseCronSchedule(tt.expr, tt.timeZone, false)
+			if err != nil {
+				t.Errorf("parseCronSchedule() error = %v", err)
+				return
+			}
+			if _, ok := sched.(yearSchedule); !ok {
+				t.Errorf("parseCronSchedule() got %T, want yearSchedule", sched)
+				return
+			}
+			if got := sched.Next(tt.t); !got.Equal(tt.want) {
+				t.Errorf("Next() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package cronrange
+
+import (
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// prevSchedule is implemented by the schedules which can search for the previous activation directly.
+type prevSchedule interface {
+	// Prev returns the latest activation time earlier than the given time, or the zero time if there's none within five years.
+	Prev(t time.Time) time.Time
+}
+
+// hasPrev checks if the schedule can search backward without probing with Next().
+func hasPrev(s cron.Schedule) bool {
+	switch s.(type) {
+	case *cron.SpecSchedule, prevSchedule:
+		return true
+	}
+	return false
+}
+
+// prevActivation returns the latest activation time of the schedule earlier than the given time, or the zero time if there's none within five years.
+// The schedules without Prev() are probed with windows doubling backward.
+func prevActivation(s cron.Schedule, t time.Time) time.Time {
+	switch ps := s.(type) {
+	case *cron.SpecSchedule:
+		return specPrev(ps, t)
+	case prevSchedule:
+		return ps.Prev(t)
+	}
+
+	before := t.Add(-time.Nanosecond)
+	for w := time.Minute; ; w *= 2 {
+		if w > maxSetSearch {
+			w = maxSetSearch
+		}
+		if last, found := probeLastActivation(s, before, w); found {
+			return last
+		}
+		if w == maxSetSearch {
+			return time.Time{}
+		}
+	}
+}
+
+// specPrev returns the latest activation time of the cron schedule earlier than the given time, or the zero time if there's none within five years.
+//
+// It's the reverse of cron.SpecSchedule.Next(): it walks the dates backward in the location of the schedule, and for each matching date,
+// walks the hours of the day backward in the absolute time, so the hours skipped by DST never activate and the hours repeated by DST activate twice,
+// just like the forward search.
+func specPrev(s *cron.SpecSchedule, t time.Time) time.Time {
+	loc := s.Location
+	if loc == time.Local {
+		loc = t.Location()
+	}
+
+	local := t.In(loc)
+	yearLimit := local.Year() - 5
+	year, month, day := local.Date()
+	for year >= yearLimit {
+		if 1<<uint(month)&s.Month == 0 {
+			// jump to the last day of the previous month
+			year, month, day = time.Date(year, month, 0, 0, 0, 0, 0, time.UTC).Date()
+			continue
+		}
+		if specDayMatches(s, year, month, day) {
+			if prev := specPrevOnDay(s, time.Date(year, month, day, 0, 0, 0, 0, loc), t); !prev.IsZero() {
+				return prev.In(t.Location())
+			}
+		}
+		year, month, day = time.Date(year, month, day-1, 0, 0, 0, 0, time.UTC).Date()
+	}
+	return time.Time{}
+}
+
+// specDayMatches checks if the date satisfies the day-of-month and day-of-week fields, in the same way as cron.SpecSchedule.
+func specDayMatches(s *cron.SpecSchedule, year int, month time.Month, day int) bool {
+	domMatch := 1<<uint(day)&s.Dom > 0
+	dowMatch := 1<<uint(weekdayOf(year, month, day))&s.Dow > 0
+	if s.Dom&starBit > 0 || s.Dow&starBit > 0 {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// specPrevOnDay returns the latest activation on the day starting at the given midnight and earlier than t, or the zero time if there's none.
+func specPrevOnDay(s *cron.SpecSchedule, midnight, t time.Time) time.Time {
+	// the midnight skipped by DST is normalized to the hour before, so move it into the day as cron.SpecSchedule does
+	day := midnight.Day()
+	if hour := midnight.Hour(); hour > 12 {
+		midnight, day = midnight.Add(time.Duration(24-hour)*time.Hour), midnight.AddDate(0, 0, 1).Day()
+	}
+
+	var hours []time.Time
+	for h := midnight; h.Day() == day; h = h.Add(time.Hour) {
+		hours = append(hours, h)
+	}
+
+	for i := len(hours) - 1; i >= 0; i-- {
+		h := hours[i]
+		if 1<<uint(h.Hour())&s.Hour == 0 || !h.Before(t) {
+			continue
+		}
+		for min := 59; min >= 0; min-- {
+			m := h.Add(time.Duration(min) * time.Minute)
+			if 1<<uint(min)&s.Minute == 0 || !m.Before(t) {
+				continue
+			}
+			for sec := 59; sec >= 0; sec-- {
+				if prev := m.Add(time.Duration(sec) * time.Second); 1<<uint(sec)&s.Second > 0 && prev.Before(t) {
+					return prev
+				}
+			}
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,118 @@
+package cronrange
+
+import (
+	"testing"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+func TestSpecPrev(t *testing.T) {
+	locationBerlin, _ := time.LoadLocation("Europe/Berlin")
+	tests := []struct {
+		name     string
+		expr     string
+		t        time.Time
+		wantPrev time.Time
+	}{
+		{"Every minute", "* * * * *", parseTime(locationUTC, "2020-01-01 00:00:30"), parseTime(locationUTC, "2020-01-01 00:00:00")},
+		{"Every minute exactly at activation", "* * * * *", parseTime(locationUTC, "2020-01-01 00:01:00"), parseTime(locationUTC, "2020-01-01 00:00:00")},
+		{"New year across years", "0 0 1 1 *", parseTime(locationUTC, "2020-01-01 00:00:00"), parseTime(locationUTC, "2019-01-01 00:00:00")},
+		{"Leap day", "0 0 29 2 *", parseTime(locationUTC, "2020-01-01 00:00:00"), parseTime(locationUTC, "2016-02-29 00:00:00")},
+		{"Never within five years", "0 0 30 2 *", parseTime(locationUTC, "2020-01-01 00:00:00"), zeroTime},
+		{"Day of month or week", "0 9 13 * 5", parseTime(locationUTC, "2020-01-12 00:00:00"), parseTime(locationUTC, "2020-01-10 09:00:00")},
+		{"Time zone of schedule", "CRON_TZ=Asia/Tokyo 0 9 * * *", parseTime(locationUTC, "2020-01-01 23:59:00"), parseTime(locationUTC, "2020-01-01 00:00:00")},
+		{"Skipped hour by DST", "30 2 * * *", parseTime(locationBerlin, "2020-03-29 12:00:00"), parseTime(locationBerlin, "2020-03-28 02:30:00")},
+		{"Repeated hour by DST", "30 2 * * *", parseTime(locationUTC, "2020-10-25 01:00:00").In(locationBerlin), parseTime(locationUTC, "2020-10-25 00:30:00").In(locationBerlin)},
+		{"Repeated hour by DST before", "30 2 * * *", parseTime(locationUTC, "2020-10-25 00:30:00").In(locationBerlin), parseTime(locationBerlin, "2020-10-24 02:30:00")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := cron.ParseStandard(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseStandard() error = %v", err)
+			}
+			if got := prevActivation(s, tt.t); !got.Equal(tt.wantPrev) {
+				t.Errorf("prevActivation() = %v, want %v", got, tt.wantPrev)
+			}
+		})
+	}
+}
+
+func TestSpecPrev_ReverseOfNext(t *testing.T) {
+	locationBerlin, _ := time.LoadLocation("Europe/Berlin")
+	locationSaoPaulo, _ := time.LoadLocation("America/Sao_Paulo")
+	locationNewYork, _ := time.LoadLocation(timeZoneNewYork)
+	exprs := []string{
+		"*/7 * * * *",
+		"30 2 * * *",
+		"0 0 * * *",
+		"15 1-3 * * 0",
+		exprVeryComplicated,
+	}
+	ranges := []struct {
+		loc   *time.Location
+		start string
+		end   string
+	}{
+		{locationBerlin, "2020-03-28 00:00:00", "2020-03-30 00:00:00"},
+		{locationBerlin, "2020-10-24 00:00:00", "2020-10-26 00:00:00"},
+		{locationNewYork, "2019-11-02 00:00:00", "2019-11-04 00:00:00"},
+		{locationSaoPaulo, "2018-11-03 00:00:00", "2018-11-05 00:00:00"},
+	}
+	for _, expr := range exprs {
+		s, err := cron.ParseStandard(expr)
+		if err != nil {
+			t.Fatalf("ParseStandard(%q) error = %v", expr, err)
+		}
+		for _, r := range ranges {
+			// the activations found backward should be the ones found forward
+			start, end := parseTime(r.loc, r.start), parseTime(r.loc, r.end)
+			var forward []time.Time
+			for next := s.Next(start.Add(-time.Nanosecond)); next.Before(end); next = s.Next(next) {
+				forward = append(forward, next)
+			}
+			i := len(forward) - 1
+			for prev := prevActivation(s, end); !prev.Before(start); prev = prevActivation(s, prev) {
+				if i < 0 || !prev.Equal(forward[i]) {
+					t.Fatalf("prevActivation(%q) in %v = %v, mismatched the forward search", expr, r.loc, prev)
+				}
+				i--
+			}
+			if i >= 0 {
+				t.Errorf("prevActivation(%q) in %v missed %v", expr, r.loc, forward[i])
+			}
+		}
+	}
+}
+
+func TestPrevActivation_Probe(t *testing.T) {
+	// the Quartz schedule has no Prev() and is probed with Next()
+	cr := crMustParse("DR=60; TZ=Asia/Tokyo; 0 9 L * *")
+	tests := []struct {
+		name     string
+		t        time.Time
+		wantPrev time.Time
+	}{
+		{"Within the month", parseTime(locationTokyo, "2020-02-29 12:00:00"), parseTime(locationTokyo, "2020-02-29 09:00:00")},
+		{"Exactly at activation", parseTime(locationTokyo, "2020-02-29 09:00:00"), parseTime(locationTokyo, "2020-01-31 09:00:00")},
+		{"Previous month", parseTime(locationTokyo, "2020-03-15 00:00:00"), parseTime(locationTokyo, "2020-02-29 09:00:00")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if hasPrev(cr.schedule) {
+				t.Fatalf("hasPrev() = true, want false")
+			}
+			if got := prevActivation(cr.schedule, tt.t); !got.Equal(tt.wantPrev) {
+				t.Errorf("prevActivation() = %v, want %v", got, tt.wantPrev)
+			}
+		})
+	}
+}
+
+func BenchmarkSpecPrev(b *testing.B) {
+	s, _ := cron.ParseStandard(exprVeryComplicated)
+	for i := 0; i < b.N; i++ {
+		_ = prevActivation(s, firstSec2019Local)
+	}
+}
@@ -0,0 +1,320 @@
+package cronrange
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+var (
+	cronParseOption       = cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor
+	cronParser            = cron.NewParser(cronParseOption)
+	cronParserWithSeconds = cron.NewParser(cron.Second | cronParseOption)
+
+	strDescriptorEvery = `@every`
+	strCronSeparator   = `|`
+
+	// everyEpoch is the moment where all the intervals of @every descriptors are counted from, i.e. the Unix epoch.
+	everyEpoch = time.Unix(0, 0).UTC()
+
+	errEveryTooShort = errors.New("interval of @every should be at least one second")
+	errEmptyCronPart = errors.New("cron expression should not be empty")
+	errStarYearField = errors.New("year field should not be a bare '*' or '?' without seconds field")
+	errEveryWithZone = errors.New("@every descriptor should not have a time zone prefix")
+	errNotSpecSched  = errors.New("cron expression should be parsed into a spec schedule")
+)
+
+// normalizeCronExpr trims the cron expressions separated by '|', and joins them with single spaces around the separators.
+func normalizeCronExpr(expr string) string {
+	parts := strings.Split(expr, strCronSeparator)
+	for i, part := range parts {
+		parts[i] = strings.TrimSpace(part)
+	}
+	return strings.Join(parts, strSingleWhitespace+strCronSeparator+strSingleWhitespace)
+}
+
+// parseSchedule parses one or more expressions separated by '|', each of which is either the @every descriptor or the cron expression,
+// and the schedules of multiple expressions are merged into one.
+func parseSchedule(expr, timeZone string, withSeconds bool) (cron.Schedule, error) {
+	parts := strings.Split(expr, strCronSeparator)
+	if len(parts) == 1 {
+		return parseSingleSchedule(expr, timeZone, withSeconds)
+	}
+
+	merged := make(multiSchedule, 0, len(parts))
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part == "" {
+			return nil, errEmptyCronPart
+		}
+		sched, err := parseSingleSchedule(part, timeZone, withSeconds)
+		if err != nil {
+			return nil, err
+		}
+		merged = append(merged, sched)
+	}
+	return merged, nil
+}
+
+// parseSingleSchedule parses either the @every descriptor, the date rule like @easter, or the cron expression.
+func parseSingleSchedule(expr, timeZone string, withSeconds bool) (cron.Schedule, error) {
+	if strings.HasPrefix(expr, strDescriptorEvery) {
+		return parseEverySchedule(expr)
+	}
+	if isDateRule(expr) {
+		return parseDateRuleSchedule(expr, timeZone)
+	}
+	return parseCronSchedule(expr, timeZone, withSeconds)
+}
+
+// parseCronSchedule parses the cron expression in the given time zone, with an optional seconds field at the beginning.
+// Besides the standard syntax, Quartz-style operators like L, W and # are supported in day-of-month and day-of-week fields,
+// and so is the optional year field at the end.
+func parseCronSchedule(expr, timeZone string, withSeconds bool) (sched cron.Schedule, err error) {
+	parser, idxDom := cronParser, 2
+	if withSeconds {
+		parser, idxDom = cronParserWithSeconds, 3
+	}
+
+	// Extract Quartz-style year field and day operators, and leave the rest to the standard parser
+	var (
+		rule  *dayRule
+		years []int
+	)
+	tzPrefix, fieldExpr := splitTimeZonePrefix(expr)
+	if strings.HasPrefix(fieldExpr, strDescriptorEvery) {
+		// the intervals of @every don't depend on time zones
+		err = fmt.Errorf("%w: %q", errEveryWithZone, expr)
+		return
+	}
+	if fields := strings.Fields(fieldExpr); !strings.HasPrefix(fieldExpr, "@") {
+		if len(fields) == idxDom+4 {
+			if yearField := fields[idxDom+3]; !withSeconds && (yearField == "*" || yearField == "?") {
+				// it's more likely a seconds-first expression without the flag than a year field matching all years
+				err = fmt.Errorf("%w: %q", errStarYearField, fieldExpr)
+				return
+			}
+			if years, err = parseYearField(fields[idxDom+3]); err != nil {
+				return
+			}
+			fields = fields[:idxDom+3]
+		}
+		if len(fields) == idxDom+3 {
+			if rule, err = parseDayRule(fields[idxDom], fields[idxDom+2]); err != nil {
+				return
+			} else if rule != nil {
+				fields[idxDom], fields[idxDom+2] = "*", "*"
+			}
+		}
+		expr = tzPrefix + strings.Join(fields, " ")
+	}
+
+	if len(timeZone) > 0 {
+		expr = fmt.Sprintf("CRON_TZ=%s %s", timeZone, expr)
+	}
+	if sched, err = parser.Parse(expr); err != nil {
+		return
+	}
+	spec, ok := sched.(*cron.SpecSchedule)
+	if !ok {
+		err = fmt.Errorf("%w: %q", errNotSpecSched, expr)
+		return nil, err
+	}
+	if rule != nil {
+		sched = quartzSchedule{spec: spec, rule: rule}
+	}
+	if years != nil {
+		sched = yearSchedule{inner: sched, years: years, loc: locationOf(spec)}
+	}
+	return
+}
+
+// splitTimeZonePrefix splits the leading CRON_TZ= or TZ= prefix (with the trailing whitespace) from the cron expression, so the fields can be counted.
+func splitTimeZonePrefix(expr string) (prefix, rest string) {
+	if strings.HasPrefix(expr, "CRON_TZ=") || strings.HasPrefix(expr, "TZ=") {
+		if idx := strings.IndexAny(expr, " \t"); idx >= 0 {
+			rest = strings.TrimLeft(expr[idx:], " \t")
+			return expr[:len(expr)-len(rest)], rest
+		}
+	}
+	return "", expr
+}
+
+// locationOf returns the location of the schedule, or nil if it's relative to the time provided.
+func locationOf(spec *cron.SpecSchedule) *time.Location {
+	if spec.Location == time.Local {
+		return nil
+	}
+	return spec.Location
+}
+
+// everySchedule represents the @every descriptor, activating at every fixed interval since the Unix epoch,
+// so unlike cron.ConstantDelaySchedule, the activations don't depend on the time when the search starts.
+type everySchedule struct {
+	interval time.Duration
+}
+
+// parseEverySchedule parses descriptors like "@every 90m" or "@every 1h30m", the interval should be exact and no shorter than a second.
+func parseEverySchedule(expr string) (s everySchedule, err error) {
+	durStr := strings.TrimSpace(strings.TrimPrefix(expr, strDescriptorEvery))
+	var per period
+	if per, s.interval, err = parseDuration(durStr); err != nil {
+		return
+	}
+	if !per.isZero() {
+		err = fmt.Errorf("%w: %q is not exact", errInvalidDuration, durStr)
+	} else if s.interval < time.Second {
+		err = errEveryTooShort
+	}
+	return
+}
+
+// Next returns the next activation time, later than the given time.
+func (s everySchedule) Next(t time.Time) time.Time {
+	elapsed := t.Sub(everyEpoch)
+	n := elapsed / s.interval
+	if elapsed < 0 && elapsed%s.interval != 0 {
+		n--
+	}
+	return everyEpoch.Add((n + 1) * s.interval).In(t.Location())
+}
+
+// Prev returns the previous activation time, earlier than the given time.
+func (s everySchedule) Prev(t time.Time) time.Time {
+	elapsed := t.Sub(everyEpoch)
+	n := elapsed / s.interval
+	if elapsed%s.interval <= 0 {
+		n--
+	}
+	return everyEpoch.Add(n * s.interval).In(t.Location())
+}
+
+// countSchedule limits the activations of the inner schedule to the ones not before the start time, and at most the given count of them if it's limited.
+type countSchedule struct {
+	inner   cron.Schedule
+	start   time.Time
+	limited bool
+	last    time.Time
+}
+
+// newCountSchedule returns a countSchedule with the last activation found in advance if the count is positive.
+func newCountSchedule(inner cron.Schedule, start time.Time, count int) (s countSchedule) {
+	s = countSchedule{inner: inner, start: start, limited: count > 0}
+	for curr, i := start.Add(-time.Nanosecond), 0; i < count; i++ {
+		next := inner.Next(curr)
+		if next.Before(curr) {
+			break
+		}
+		s.last, curr = next, next
+	}
+	return
+}
+
+// Next returns the next activation time, later than the given time. It returns the zero time if the count runs out.
+func (s countSchedule) Next(t time.Time) time.Time {
+	if s.limited && s.last.IsZero() {
+		return time.Time{}
+	}
+	if t.Before(s.start) {
+		t = s.start.Add(-time.Nanosecond).In(t.Location())
+	}
+	next := s.inner.Next(t)
+	if s.limited && next.After(s.last) {
+		return time.Time{}
+	}
+	return next
+}
+
+// Prev returns the previous activation time, earlier than the given time. It returns the zero time if it's before the start time.
+func (s countSchedule) Prev(t time.Time) time.Time {
+	if s.limited && s.last.IsZero() {
+		return time.Time{}
+	}
+	if s.limited && t.After(s.last) {
+		t = s.last.Add(time.Nanosecond).In(t.Location())
+	}
+	prev := prevActivation(s.inner, t)
+	if prev.Before(s.start) {
+		return time.Time{}
+	}
+	return prev
+}
+
+// offsetSchedule shifts the activations of the inner schedule by the offset, which can be negative for a lead time.
+type offsetSchedule struct {
+	inner  cron.Schedule
+	offset time.Duration
+}
+
+// Next returns the next shifted activation time, later than the given time. It returns the zero time if the inner schedule does.
+func (s offsetSchedule) Next(t time.Time) time.Time {
+	next := s.inner.Next(t.Add(-s.offset))
+	if next.IsZero() {
+		return next
+	}
+	return next.Add(s.offset)
+}
+
+// Prev returns the previous shifted activation time, earlier than the given time. It returns the zero time if the inner schedule does.
+func (s offsetSchedule) Prev(t time.Time) time.Time {
+	prev := prevActivation(s.inner, t.Add(-s.offset))
+	if prev.IsZero() {
+		return prev
+	}
+	return prev.Add(s.offset)
+}
+
+// parseOffset parses the value of OFF= part, which is a signed exact duration like "-30m" or "+2h" in the format of DR= part.
+func parseOffset(s string) (offset time.Duration, err error) {
+	negative := strings.HasPrefix(s, "-")
+	if negative || strings.HasPrefix(s, "+") {
+		s = s[1:]
+	}
+
+	var p period
+	if p, offset, err = parseDurationExpr(s); err != nil {
+		return
+	} else if !p.isZero() {
+		err = fmt.Errorf("%w: nominal units are not allowed in offset: %q", errInvalidDuration, s)
+		return
+	}
+	if negative {
+		offset = -offset
+	}
+	return
+}
+
+// formatOffset returns the value of OFF= part.
+func formatOffset(offset time.Duration, iso bool) string {
+	if offset < 0 {
+		return "-" + formatDurationExpr(period{}, -offset, iso)
+	}
+	return formatDurationExpr(period{}, offset, iso)
+}
+
+// multiSchedule merges the activations of multiple schedules in chronological order.
+type multiSchedule []cron.Schedule
+
+// Next returns the earliest activation time of all the schedules, later than the given time.
+// It returns the zero time if none of the schedules activates.
+func (s multiSchedule) Next(t time.Time) (next time.Time) {
+	for _, sched := range s {
+		if n := sched.Next(t); !n.IsZero() && (next.IsZero() || n.Before(next)) {
+			next = n
+		}
+	}
+	return
+}
+
+// Prev returns the latest activation time of all the schedules, earlier than the given time.
+// It returns the zero time if none of the schedules activates.
+func (s multiSchedule) Prev(t time.Time) (prev time.Time) {
+	for _, sched := range s {
+		if p := prevActivation(sched, t); !p.IsZero() && p.After(prev) {
+			prev = p
+		}
+	}
+	return
+}
@@ -0,0 +1,224 @@
+package cronrange
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseEverySchedule(t *testing.T) {
+	tests := []struct {
+		name    string
+		expr    string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"Missing interval", "@every", 0, true},
+		{"Invalid interval", "@every hour", 0, true},
+		{"Zero interval", "@every 0s", 0, true},
+		{"Too short interval", "@every 500ms", 0, true},
+		{"Nominal interval", "@every 1mo", 0, true},
+		{"Plain minutes", "@every 90", 90 * time.Minute, false},
+		{"Hours and minutes", "@every 1h30m", 90 * time.Minute, false},
+		{"Days", "@every  2d", 48 * time.Hour, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseEverySchedule(tt.expr)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseEverySchedule() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.interval != tt.want {
+				t.Errorf("parseEverySchedule() got = %v, want %v", got.interval, tt.want)
+			}
+		})
+	}
+}
+
+func TestEverySchedule_Next(t *testing.T) {
+	tests := []struct {
+		name     string
+		interval time.Duration
+		t        time.Time
+		want     time.Time
+	}{
+		{"Every 90 minutes at epoch", 90 * time.Minute, everyEpoch, everyEpoch.Add(90 * time.Minute)},
+		{"Every 90 minutes before epoch", 90 * time.Minute, everyEpoch.Add(-time.Second), everyEpoch},
+		{"Every 90 minutes long before epoch", 90 * time.Minute, everyEpoch.Add(-90 * time.Minute), everyEpoch},
+		{"Every 90 minutes in 2020", 90 * time.Minute, firstSec2020Utc, parseTime(locationUTC, "2020-01-01 01:30:00")},
+		{"Every 90 minutes in 2020 in Bangkok", 90 * time.Minute, parseTime(locationBangkok, "2020-01-01 07:00:00"), parseTime(locationBangkok, "2020-01-01 08:30:00")},
+		{"Every 7 minutes in 2020", 7 * time.Minute, parseTime(locationUTC, "2020-01-01 00:00:30"), parseTime(locationUTC, "2020-01-01 00:05:00")},
+		{"Every 7 minutes at activation", 7 * time.Minute, parseTime(locationUTC, "2020-01-01 00:05:00"), parseTime(locationUTC, "2020-01-01 00:12:00")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := everySchedule{tt.interval}.Next(tt.t)
+			if !got.Equal(tt.want) {
+				t.Errorf("Next() = %v, want %v", got, tt.want)
+			}
+			if got.Location() != tt.t.Location() {
+				t.Errorf("Next() location = %v, want %v", got.Location(), tt.t.Location())
+			}
+		})
+	}
+}
+
+func BenchmarkEverySchedule_Next(b *testing.B) {
+	s := everySchedule{90 * time.Minute}
+	for i := 0; i < b.N; i++ {
+		_ = s.Next(firstSec2020Utc)
+	}
+}
+
+func TestCountSchedule_Next(t *testing.T) {
+	start := parseTime(locationUTC, "2020-01-01 00:00:00")
+	tests := []struct {
+		name  string
+		expr  string
+		count int
+		t     time.Time
+		want  time.Time
+	}{
+		{"Unlimited before start", "0 0 * * *", 0, parseTime(locationUTC, "2019-06-01 00:00:00"), parseTime(locationUTC, "2020-01-01 00:00:00")},
+		{"Unlimited after start", "0 0 * * *", 0, parseTime(locationUTC, "2021-06-01 00:00:00"), parseTime(locationUTC, "2021-06-02 00:00:00")},
+		{"Limited before start", "0 0 * * *", 3, parseTime(locationUTC, "2019-06-01 00:00:00"), parseTime(locationUTC, "2020-01-01 00:00:00")},
+		{"Limited before start in Bangkok", "0 0 * * *", 3, parseTime(locationBangkok, "2019-06-01 00:00:00"), parseTime(locationBangkok, "2020-01-01 07:00:00")},
+		{"Limited at last", "0 0 * * *", 3, parseTime(locationUTC, "2020-01-02 00:00:00"), parseTime(locationUTC, "2020-01-03 00:00:00")},
+		{"Limited after last", "0 0 * * *", 3, parseTime(locationUTC, "2020-01-03 00:00:00"), zeroTime},
+		{"Limited but never activates", "0 0 1 1 * 2019", 3, parseTime(locationUTC, "2019-06-01 00:00:00"), zeroTime},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inner, err := parseCronSchedule(tt.expr, timeZoneUTC, false)
+			if err != nil {
+				t.Errorf("parseCronSchedule() error = %v", err)
+				return
+			}
+			got := newCountSchedule(inner, start, tt.count).Next(tt.t)
+			if !got.Equal(tt.want) {
+				t.Errorf("Next() = %v, want %v", got, tt.want)
+			}
+			if !got.IsZero() && got.Location() != tt.t.Location() {
+				t.Errorf("Next() location = %v, want %v", got.Location(), tt.t.Location())
+			}
+		})
+	}
+}
+
+func TestNormalizeCronExpr(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+		want string
+	}{
+		{"Single", " 0 9 * * * ", "0 9 * * *"},
+		{"Multiple", "0 9 * * 1-5|0 11 * * 0,6", "0 9 * * 1-5 | 0 11 * * 0,6"},
+		{"Multiple with spaces", " @daily  |   @every 1h ", "@daily | @every 1h"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := normalizeCronExpr(tt.expr); got != tt.want {
+				t.Errorf("normalizeCronExpr() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMultiSchedule_Next(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+		t    time.Time
+		want time.Time
+	}{
+		{"First of both", "0 9 * * * | 0 11 * * *", parseTime(locationUTC, "2020-01-01 08:00:00"), parseTime(locationUTC, "2020-01-01 09:00:00")},
+		{"Second of both", "0 9 * * * | 0 11 * * *", parseTime(locationUTC, "2020-01-01 09:00:00"), parseTime(locationUTC, "2020-01-01 11:00:00")},
+		{"Same activation", "0 9 * * * | 0 9 1 * *", parseTime(locationUTC, "2020-01-01 08:00:00"), parseTime(locationUTC, "2020-01-01 09:00:00")},
+		{"One runs out", "0 9 * * * 2019 | 0 11 * * *", parseTime(locationUTC, "2020-01-01 08:00:00"), parseTime(locationUTC, "2020-01-01 11:00:00")},
+		{"Both run out", "0 9 * * * 2019 | 0 11 * * * 2018", parseTime(locationUTC, "2020-01-01 08:00:00"), zeroTime},
+		{"Every descriptor", "@every 7m | 0 11 * * *", parseTime(locationUTC, "2020-01-01 10:59:00"), parseTime(locationUTC, "2020-01-01 11:00:00")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sched, err := parseSchedule(tt.expr, timeZoneUTC, false)
+			if err != nil {
+				t.Errorf("parseSchedule() error = %v", err)
+				return
+			}
+			if _, ok := sched.(multiSchedule); !ok {
+				t.Errorf("parseSchedule() got %T, want multiSchedule", sched)
+			}
+			if got := sched.Next(tt.t); !got.Equal(tt.want) {
+				t.Errorf("Next() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseOffset(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    time.Duration
+		wantS   string
+		wantErr bool
+	}{
+		{"Empty", "", 0, "", true},
+		{"Sign only", "-", 0, "", true},
+		{"Double sign", "--30m", 0, "", true},
+		{"Nominal unit", "1w", 0, "", true},
+		{"Nominal ISO", "-P1D", 0, "", true},
+		{"Plain minutes", "30", 30 * time.Minute, "30", false},
+		{"Negative minutes", "-30m", -30 * time.Minute, "-30", false},
+		{"Positive hours", "+2h", 2 * time.Hour, "120", false},
+		{"Negative seconds", "-90s", -90 * time.Second, "-1m30s", false},
+		{"Negative ISO", "-PT1H30M", -90 * time.Minute, "-90", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseOffset(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseOffset() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got != tt.want {
+				t.Errorf("parseOffset() got = %v, want %v", got, tt.want)
+			}
+			if gotS := formatOffset(got, false); gotS != tt.wantS {
+				t.Errorf("formatOffset() got = %v, want %v", gotS, tt.wantS)
+			}
+		})
+	}
+}
+
+func TestOffsetSchedule_Next(t *testing.T) {
+	tests := []struct {
+		name   string
+		expr   string
+		offset time.Duration
+		t      time.Time
+		want   time.Time
+	}{
+		{"Lead time before", "0 9 * * *", -30 * time.Minute, parseTime(locationUTC, "2020-01-01 08:00:00"), parseTime(locationUTC, "2020-01-01 08:30:00")},
+		{"Lead time at", "0 9 * * *", -30 * time.Minute, parseTime(locationUTC, "2020-01-01 08:30:00"), parseTime(locationUTC, "2020-01-02 08:30:00")},
+		{"Lead time between", "0 9 * * *", -30 * time.Minute, parseTime(locationUTC, "2020-01-01 08:45:00"), parseTime(locationUTC, "2020-01-02 08:30:00")},
+		{"Delay across days", "0 0 L * *", 2 * time.Hour, parseTime(locationUTC, "2020-01-31 01:00:00"), parseTime(locationUTC, "2020-01-31 02:00:00")},
+		{"Lead time across months", "0 0 1 * *", -time.Hour, parseTime(locationUTC, "2020-01-01 00:00:00"), parseTime(locationUTC, "2020-01-31 23:00:00")},
+		{"Never activates", "0 0 1 1 * 2019", time.Hour, parseTime(locationUTC, "2020-01-01 00:00:00"), zeroTime},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inner, err := parseSchedule(tt.expr, timeZoneUTC, false)
+			if err != nil {
+				t.Errorf("parseSchedule() error = %v", err)
+				return
+			}
+			if got := (offsetSchedule{inner: inner, offset: tt.offset}).Next(tt.t); !got.Equal(tt.want) {
+				t.Errorf("Next() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -14,21 +14,64 @@ var (
 	strDoubleQuotation  = `"`
 	strSemicolon        = `;`
 	strMarkDuration     = `DR=`
+	strMarkEnd          = `END=`
 	strMarkTimeZone     = `TZ=`
+	strMarkSeconds      = `SEC=`
+	strMarkNotBefore    = `NB=`
+	strMarkNotAfter     = `NA=`
+	strMarkCount        = `CNT=`
+	strMarkStartAt      = `START=`
+	strMarkExcept       = `EXCEPT=`
+	strMarkEvery        = `EVERY=`
+	strMarkAnchor       = `ANCHOR=`
+	strMarkOffset       = `OFF=`
+	strMarkBoundary     = `BD=`
+	strMarkDST          = `DST=`
+	strTrue             = `1`
 
 	errIncompleteExpr     = errors.New("expression should contain at least two parts")
-	errMissDurationExpr   = errors.New("duration is missing from the expression")
+	errMissDurationExpr   = errors.New("duration or end expression is missing from the expression")
 	errEmptyExpr          = errors.New("expression is empty")
+	errExceptNoBracket    = errors.New("except expression should be enclosed in square brackets")
 	errJSONNoQuotationFix = errors.New(`json string should start and end with '"'`)
 )
 
+// StringOption controls the textual form of CronRange expression returned by StringWith().
+type StringOption uint8
+
+const (
+	// ISODuration formats the duration in ISO 8601 format like "PT1H30M" instead of the plain number of minutes.
+	ISODuration StringOption = 1 << iota
+	// Shorthand formats the cron expression and end expression as a wall-clock time window like "Mon-Fri 09:00-17:00" if they're representable,
+	// otherwise the canonical form is returned.
+	Shorthand
+)
+
 // String returns a normalized CronRange expression, which can be consumed by ParseString().
 func (cr CronRange) String() string {
+	return cr.StringWith(0)
+}
+
+// StringWith returns a normalized CronRange expression in the form controlled by the given options,
+// which can also be consumed by ParseString().
+func (cr CronRange) StringWith(opt StringOption) string {
+	expr, endExpr := cr.cronExpression, cr.endExpression
+	if opt&Shorthand != 0 && !cr.withSeconds {
+		if window, ok := formatTimeWindow(cr.cronExpression, cr.endExpression); ok {
+			expr, endExpr = window, ""
+		}
+	}
+
 	sb := strings.Builder{}
 	sb.Grow(36)
-	if cr.duration > 0 {
+	if len(endExpr) > 0 {
+		sb.WriteString(strMarkEnd)
+		sb.WriteString(endExpr)
+		sb.WriteString(strSemicolon)
+		sb.WriteString(strSingleWhitespace)
+	} else if len(cr.endExpression) == 0 && (cr.duration > 0 || !cr.period.isZero()) {
 		sb.WriteString(strMarkDuration)
-		sb.WriteString(strconv.FormatUint(uint64(cr.duration/time.Minute), 10))
+		sb.WriteString(formatDurationExpr(cr.period, cr.duration, opt&ISODuration != 0))
 		sb.WriteString(strSemicolon)
 		sb.WriteString(strSingleWhitespace)
 	}
@@ -38,11 +81,119 @@ func (cr CronRange) String() string {
 		sb.WriteString(strSemicolon)
 		sb.WriteString(strSingleWhitespace)
 	}
-	sb.WriteString(cr.cronExpression)
+	if cr.withSeconds {
+		sb.WriteString(strMarkSeconds)
+		sb.WriteString(strTrue)
+		sb.WriteString(strSemicolon)
+		sb.WriteString(strSingleWhitespace)
+	}
+	if cr.boundary != ClosedBoundary {
+		sb.WriteString(strMarkBoundary)
+		sb.WriteString(cr.boundary.String())
+		sb.WriteString(strSemicolon)
+		sb.WriteString(strSingleWhitespace)
+	}
+	if cr.dstMode != AbsoluteDST {
+		sb.WriteString(strMarkDST)
+		sb.WriteString(cr.dstMode.String())
+		sb.WriteString(strSemicolon)
+		sb.WriteString(strSingleWhitespace)
+	}
+	if !cr.notBefore.IsZero() {
+		sb.WriteString(strMarkNotBefore)
+		sb.WriteString(cr.notBefore.Format(time.RFC3339Nano))
+		sb.WriteString(strSemicolon)
+		sb.WriteString(strSingleWhitespace)
+	}
+	if !cr.notAfter.IsZero() {
+		sb.WriteString(strMarkNotAfter)
+		sb.WriteString(cr.notAfter.Format(time.RFC3339Nano))
+		sb.WriteString(strSemicolon)
+		sb.WriteString(strSingleWhitespace)
+	}
+	if cr.count > 0 {
+		sb.WriteString(strMarkCount)
+		sb.WriteString(strconv.Itoa(cr.count))
+		sb.WriteString(strSemicolon)
+		sb.WriteString(strSingleWhitespace)
+	}
+	if !cr.startAt.IsZero() {
+		sb.WriteString(strMarkStartAt)
+		sb.WriteString(cr.startAt.Format(time.RFC3339Nano))
+		sb.WriteString(strSemicolon)
+		sb.WriteString(strSingleWhitespace)
+	}
+	if !cr.interval.isZero() {
+		sb.WriteString(strMarkEvery)
+		sb.WriteString(formatInterval(cr.interval.count, cr.interval.weeks))
+		sb.WriteString(strSemicolon)
+		sb.WriteString(strSingleWhitespace)
+		sb.WriteString(strMarkAnchor)
+		sb.WriteString(cr.interval.anchor.Format(strDateLayout))
+		sb.WriteString(strSemicolon)
+		sb.WriteString(strSingleWhitespace)
+	}
+	if cr.offset != 0 {
+		sb.WriteString(strMarkOffset)
+		sb.WriteString(formatOffset(cr.offset, opt&ISODuration != 0))
+		sb.WriteString(strSemicolon)
+		sb.WriteString(strSingleWhitespace)
+	}
+	for _, ex := range cr.exceptionRanges() {
+		sb.WriteString(strMarkExcept)
+		sb.WriteString("[")
+		sb.WriteString(ex.StringWith(opt))
+		sb.WriteString("]")
+		sb.WriteString(strSemicolon)
+		sb.WriteString(strSingleWhitespace)
+	}
+	sb.WriteString(expr)
 	return sb.String()
 }
 
 // ParseString attempts to deserialize the given expression or return failure if any parsing errors occur.
+//
+// The duration in DR= part can be written in ISO 8601 format as well, e.g. "DR=PT2H30M" or "DR=P1DT4H",
+// the nominal years, months, weeks and days are resolved on the calendar of the time zone in TZ= part.
+//
+// Instead of the DR= part, an END= part can set a cron expression for the ending time, so each time range ends at the first activation of it after the start,
+// e.g. "END=0 6 * * 1; TZ=Europe/Berlin; 0 18 * * 5" stands for Friday 18:00 until Monday 06:00 in Berlin.
+//
+// The optional SEC=1 part enables the seconds field at the beginning of the cron expression, e.g. "DR=5; SEC=1; 30 0 9 * * *" starts at 09:00:30.
+//
+// The optional BD= part sets whether the starting time and ending time are included in the time ranges, which can be closed (default),
+// closed-open, open-closed or open, e.g. "DR=60; BD=closed-open; 0 * * * *" stands for the hourly time ranges not sharing the top of the hour.
+//
+// The optional DST= part sets how the time ranges are resolved on the days of DST transitions, which can be abs (default) or wall.
+// With abs, the duration is the exact elapsed time, the activations in a spring-forward gap are skipped and the ones in a repeated fall-back hour happen twice.
+// With wall, the cron expression and duration are applied on the wall clock in the time zone, e.g. "DR=1440; TZ=Europe/Berlin; DST=wall; 0 0 * * *" always ends at the next midnight,
+// the wall clock skipped by a spring-forward gap is shifted forward by the length of the gap, and the wall clock repeated by a fall-back transition resolves to its first occurrence.
+// The time range starting in the gap is shifted forward as a whole, so is the one ending by the END= part. The OFF= part is always the exact elapsed time.
+//
+// The optional NB= and NA= parts set the validity bounds in RFC 3339 format, e.g. "DR=60; NB=2025-01-01T00:00:00Z; NA=2025-03-31T23:59:59Z; 0 9 * * *",
+// so the time ranges are only active between them.
+//
+// The optional START= part in RFC 3339 format ignores the time ranges starting before it, and along with it, the optional CNT= part limits the number of time ranges,
+// e.g. "DR=15; CNT=10; START=2025-01-06T00:00:00Z; 0 9 * * 1" stands for the first 10 Monday standups since 2025-01-06. The count is at most 10000.
+//
+// Named date rules of movable feasts and holidays can be used like descriptors, with an optional offset of days,
+// e.g. "DR=1440; TZ=Europe/Berlin; @easter-2" stands for Good Friday. Valid names are easter, orthodox-easter, thanksgiving (US),
+// memorial-day (US), labor-day (US), mothers-day (US) and fathers-day (US).
+//
+// The optional EVERY= part along with the ANCHOR= part keeps the activations on the first day or week of each interval counted from the anchor date and drops the ones before it,
+// e.g. "DR=60; EVERY=2w; ANCHOR=2025-01-07; 0 9 * * 2" stands for every other Tuesday since 2025-01-07.
+//
+// The optional OFF= part shifts the starting time of each time range from the activation by a signed duration,
+// e.g. "DR=45; OFF=-30m; 0 9 * * *" starts 30 minutes before 09:00 every day.
+//
+// The optional EXCEPT= parts set the exclusions in CronRange expressions enclosed in square brackets, each of which has its own time zone,
+// e.g. "DR=480; EXCEPT=[DR=1440; 0 0 * * 1#1]; 0 9 * * 1-5" stands for the business hours except the first Monday of each month.
+//
+// Multiple cron expressions separated by '|' share the duration and time zone, and their time ranges are merged in chronological order,
+// e.g. "DR=120; 0 9 * * 1-5 | 0 11 * * 0,6" starts at 09:00 on weekdays and 11:00 on weekends.
+//
+// The cron expression can be replaced by a wall-clock time window with optional days of week, e.g. "TZ=Europe/Berlin; Mon-Fri 09:00-17:00" for the business hours,
+// and "22:00-06:00" for the overnight window. It's compiled into the cron expression and end expression, and can't be used along with DR=, END= or SEC=1 parts.
 func ParseString(s string) (cr *CronRange, err error) {
 	if s == "" {
 		err = errEmptyExpr
@@ -50,12 +201,13 @@ func ParseString(s string) (cr *CronRange, err error) {
 	}
 
 	var (
-		cronExpr, timeZone, durStr string
-		durMin                     uint64
-		parts                      = strings.Split(s, strSemicolon)
-		idxExpr                    = len(parts) - 1
+		durStr  string
+		draft   CronRange
+		parts   = splitParts(s)
+		idxExpr = len(parts) - 1
 	)
-	if idxExpr == 0 {
+	if idxExpr == 0 && !isTimeWindow(s) {
+		// the time window alone is a complete expression
 		err = errIncompleteExpr
 		return
 	}
@@ -71,22 +223,91 @@ PL:
 		switch {
 		case idx == idxExpr:
 			// cron expression must be the last part
-			cronExpr = part
+			draft.cronExpression = part
 		case strings.HasPrefix(part, strMarkDuration):
 			durStr = part[len(strMarkDuration):]
-			if durMin, err = strconv.ParseUint(durStr, 10, 64); err != nil {
+			if draft.period, draft.duration, err = parseDurationExpr(durStr); err != nil {
 				break PL
 			}
+		case strings.HasPrefix(part, strMarkEnd):
+			draft.endExpression = part[len(strMarkEnd):]
 		case strings.HasPrefix(part, strMarkTimeZone):
-			timeZone = part[len(strMarkTimeZone):]
+			draft.timeZone = part[len(strMarkTimeZone):]
+		case strings.HasPrefix(part, strMarkSeconds):
+			if draft.withSeconds, err = strconv.ParseBool(part[len(strMarkSeconds):]); err != nil {
+				break PL
+			}
+		case strings.HasPrefix(part, strMarkBoundary):
+			if draft.boundary, err = parseBoundary(part[len(strMarkBoundary):]); err != nil {
+				break PL
+			}
+		case strings.HasPrefix(part, strMarkDST):
+			if draft.dstMode, err = parseDSTMode(part[len(strMarkDST):]); err != nil {
+				break PL
+			}
+		case strings.HasPrefix(part, strMarkNotBefore):
+			if draft.notBefore, err = time.Parse(time.RFC3339, part[len(strMarkNotBefore):]); err != nil {
+				break PL
+			}
+		case strings.HasPrefix(part, strMarkNotAfter):
+			if draft.notAfter, err = time.Parse(time.RFC3339, part[len(strMarkNotAfter):]); err != nil {
+				break PL
+			}
+		case strings.HasPrefix(part, strMarkCount):
+			if draft.count, err = strconv.Atoi(part[len(strMarkCount):]); err != nil {
+				break PL
+			}
+		case strings.HasPrefix(part, strMarkStartAt):
+			if draft.startAt, err = time.Parse(time.RFC3339, part[len(strMarkStartAt):]); err != nil {
+				break PL
+			}
+		case strings.HasPrefix(part, strMarkEvery):
+			if draft.interval.count, draft.interval.weeks, err = parseInterval(part[len(strMarkEvery):]); err != nil {
+				break PL
+			}
+		case strings.HasPrefix(part, strMarkAnchor):
+			if draft.interval.anchor, err = time.Parse(strDateLayout, part[len(strMarkAnchor):]); err != nil {
+				break PL
+			}
+		case strings.HasPrefix(part, strMarkOffset):
+			if draft.offset, err = parseOffset(part[len(strMarkOffset):]); err != nil {
+				break PL
+			}
+		case strings.HasPrefix(part, strMarkExcept):
+			exExpr := strings.TrimSpace(part[len(strMarkExcept):])
+			if !(strings.HasPrefix(exExpr, "[") && strings.HasSuffix(exExpr, "]")) {
+				err = errExceptNoBracket
+				break PL
+			}
+			var ex *CronRange
+			if ex, err = ParseString(exExpr[1 : len(exExpr)-1]); err != nil {
+				break PL
+			}
+			if draft.exceptions == nil {
+				draft.exceptions = &exceptionList{}
+			}
+			draft.exceptions.ranges = append(draft.exceptions.ranges, ex)
 		default:
 			err = fmt.Errorf(`expression got unknown part: %q`, part)
+			break PL
+		}
+	}
+
+	if err == nil && isTimeWindow(draft.cronExpression) {
+		if len(durStr) > 0 || len(draft.endExpression) > 0 || draft.withSeconds {
+			err = errWindowWithDuration
+			return
+		}
+		if draft.cronExpression, draft.endExpression, err = parseTimeWindow(draft.cronExpression); err != nil {
+			return
 		}
 	}
 
 	if err == nil {
-		if len(durStr) > 0 {
-			cr, err = New(cronExpr, timeZone, durMin)
+		if len(durStr) > 0 || len(draft.endExpression) > 0 {
+			if err = draft.init(); err == nil {
+				cr = &draft
+			}
 		} else {
 			err = errMissDurationExpr
 		}
@@ -94,6 +315,27 @@ PL:
 	return
 }
 
+// splitParts splits the expression into parts by semicolons, except the ones enclosed in square brackets.
+func splitParts(s string) (parts []string) {
+	depth, last := 0, 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '[':
+			depth++
+		case ']':
+			if depth > 0 {
+				depth--
+			}
+		case strSemicolon[0]:
+			if depth == 0 {
+				parts = append(parts, s[last:i])
+				last = i + 1
+			}
+		}
+	}
+	return append(parts, s[last:])
+}
+
 // MarshalJSON implements the encoding/json.Marshaler interface for serialization of CronRange.
 func (cr CronRange) MarshalJSON() ([]byte, error) {
 	expr := cr.String()
@@ -67,6 +67,64 @@ var deserializeTestCases = []struct {
 	{"Invalid with unknown part", "DR=10; TZ=Pacific/Honolulu; SET=1; * * * * *", emptyString, true},
 	{"Invalid with lower case", "dr=5;* * * * *", emptyString, true},
 	{"Invalid with wrong order", "* * * * *; DR=5;", emptyString, true},
+	{"Invalid duration with unknown unit", "DR=5x;* * * * *", emptyString, true},
+	{"Invalid duration with missing unit", "DR=1h30;* * * * *", emptyString, true},
+	{"Invalid duration with zero seconds", "DR=0s;* * * * *", emptyString, true},
+	{"Invalid ISO duration", "DR=P1H;* * * * *", emptyString, true},
+	{"Invalid zero ISO duration", "DR=PT0S;* * * * *", emptyString, true},
+	{"Invalid too many years", "DR=300y; TZ=Etc/UTC; 0 0 1 1 *", emptyString, true},
+	{"Invalid too many ISO years", "DR=P300Y; TZ=Etc/UTC; 0 0 1 1 *", emptyString, true},
+	{"Invalid too many years with hours", "DR=P280YT2562047H; TZ=Etc/UTC; 0 0 1 1 *", emptyString, true},
+	{"Invalid with unknown part before duration", "SET=1; DR=10; * * * * *", emptyString, true},
+	{"Invalid seconds flag", "DR=5; SEC=yes; * * * * *", emptyString, true},
+	{"Invalid seconds and year fields without flag", "DR=5; 30 0 9 * * * 2020", emptyString, true},
+	{"Invalid seconds flag without seconds field", "DR=5; SEC=1; 0 9 * * *", emptyString, true},
+	{"Invalid descriptor", "DR=5; @fortnightly", emptyString, true},
+	{"Invalid every descriptor", "DR=5; @every 1mo", emptyString, true},
+	{"Invalid every descriptor with Mars time zone", "DR=5; TZ=Mars; @every 1h", emptyString, true},
+	{"Invalid nth day of week", "DR=60; 0 10 * * 1#9", emptyString, true},
+	{"Invalid year", "DR=5; 0 0 28 11 * 1900", emptyString, true},
+	{"Invalid seconds-first fields without flag", "DR=5; 0 0 9 * * *", emptyString, true},
+	{"Invalid every descriptor with time zone prefix", "DR=60; CRON_TZ=UTC @every 5m", emptyString, true},
+	{"Invalid every descriptor with short time zone prefix", "DR=60; TZ=UTC @every 5m", emptyString, true},
+	{"Invalid star year", "DR=5; 0 9 * * * ?", emptyString, true},
+	{"Invalid year range", "DR=5; 0 0 28 11 * 2027-2025", emptyString, true},
+	{"Invalid year step", "DR=5; 0 0 28 11 * 2025/0", emptyString, true},
+	{"Invalid year with seconds", "DR=5; SEC=1; 0 0 0 28 11 * 20x5", emptyString, true},
+	{"Invalid not-before bound", "DR=5; NB=2025-01-01; * * * * *", emptyString, true},
+	{"Invalid not-after bound", "DR=5; NA=tomorrow; * * * * *", emptyString, true},
+	{"Invalid reversed bounds", "DR=5; NB=2025-01-02T00:00:00Z; NA=2025-01-01T00:00:00Z; * * * * *", emptyString, true},
+	{"Invalid count", "DR=5; CNT=ten; START=2025-01-06T00:00:00Z; * * * * *", emptyString, true},
+	{"Invalid negative count", "DR=5; CNT=-1; START=2025-01-06T00:00:00Z; * * * * *", emptyString, true},
+	{"Invalid too large count", "DR=5; CNT=10001; START=2025-01-06T00:00:00Z; * * * * *", emptyString, true},
+	{"Invalid huge count", "DR=5; CNT=2147483647; START=2025-01-06T00:00:00Z; * * * * *", emptyString, true},
+	{"Invalid count without start", "DR=5; CNT=10; * * * * *", emptyString, true},
+	{"Invalid start", "DR=5; CNT=10; START=2025-01-06; * * * * *", emptyString, true},
+	{"Invalid time window", "TZ=Europe/Berlin; Mon-Fri 09:00", emptyString, true},
+	{"Invalid time window with duration", "DR=60; Mon-Fri 09:00-17:00", emptyString, true},
+	{"Invalid time window with end expression", "END=0 17 * * *; Mon-Fri 09:00-17:00", emptyString, true},
+	{"Invalid time window with seconds flag", "SEC=1; Mon-Fri 09:00-17:00", emptyString, true},
+	{"Invalid end expression", "END=0 6 * *; 0 18 * * 5", emptyString, true},
+	{"Invalid end expression with duration", "DR=60; END=0 6 * * 1; 0 18 * * 5", emptyString, true},
+	{"Invalid empty end expression", "END= ; 0 18 * * 5", emptyString, true},
+	{"Invalid empty cron part", "DR=120; 0 9 * * 1-5 || 0 11 * * 0,6", emptyString, true},
+	{"Invalid trailing cron part", "DR=120; 0 9 * * 1-5 |", emptyString, true},
+	{"Invalid second cron part", "DR=120; 0 9 * * 1-5 | 0 11 * *", emptyString, true},
+	{"Invalid except without brackets", "DR=480; EXCEPT=DR=1440; 0 0 * * 1#1; 0 9 * * 1-5", emptyString, true},
+	{"Invalid except expression", "DR=480; EXCEPT=[DR=0; 0 0 * * 1#1]; 0 9 * * 1-5", emptyString, true},
+	{"Invalid unclosed except bracket", "DR=480; EXCEPT=[DR=1440; 0 0 * * 1#1; 0 9 * * 1-5", emptyString, true},
+	{"Invalid date rule offset", "DR=1440; @easter+999", emptyString, true},
+	{"Invalid date rule name", "DR=1440; @eastern", emptyString, true},
+	{"Invalid interval", "DR=60; EVERY=2x; ANCHOR=2025-01-07; 0 9 * * 2", emptyString, true},
+	{"Invalid anchor", "DR=60; EVERY=2w; ANCHOR=2025-01-07T00:00:00Z; 0 9 * * 2", emptyString, true},
+	{"Invalid interval without anchor", "DR=60; EVERY=2w; 0 9 * * 2", emptyString, true},
+	{"Invalid anchor without interval", "DR=60; ANCHOR=2025-01-07; 0 9 * * 2", emptyString, true},
+	{"Invalid offset", "DR=45; OFF=-30x; 0 9 * * *", emptyString, true},
+	{"Invalid nominal offset", "DR=45; OFF=1mo; 0 9 * * *", emptyString, true},
+	{"Invalid boundary", "DR=60; BD=half; 0 * * * *", emptyString, true},
+	{"Invalid upper case boundary", "DR=60; BD=OPEN; 0 * * * *", emptyString, true},
+	{"Invalid DST mode", "DR=60; DST=local; 0 * * * *", emptyString, true},
+	{"Invalid upper case DST mode", "DR=60; DST=WALL; 0 * * * *", emptyString, true},
 	{"Normal without timezone", "DR=5;* * * * *", "DR=5; * * * * *", false},
 	{"Normal with extra whitespaces", "  DR=6 ;  * * * * *  ", "DR=6; * * * * *", false},
 	{"Normal with empty parts", ";  DR=7;;; ;; ;; ;* * * * *  ", "DR=7; * * * * *", false},
@@ -75,6 +133,54 @@ var deserializeTestCases = []struct {
 	{"Normal with UTC time zone", "DR=9;TZ=Etc/UTC;* * * * *", "DR=9; TZ=Etc/UTC; * * * * *", false},
 	{"Normal with Honolulu time zone", "DR=10;TZ=Pacific/Honolulu;* * * * *", "DR=10; TZ=Pacific/Honolulu; * * * * *", false},
 	{"Normal with Honolulu time zone in different order", "TZ=Pacific/Honolulu; DR=10; * * * * *", "DR=10; TZ=Pacific/Honolulu; * * * * *", false},
+	{"Normal with seconds duration", "DR=90s;* * * * *", "DR=1m30s; * * * * *", false},
+	{"Normal with hours and minutes duration", "DR=1h30m;* * * * *", "DR=90; * * * * *", false},
+	{"Normal with days duration", "DR=2d;TZ=Asia/Tokyo;0 0 1 1 *", "DR=2880; TZ=Asia/Tokyo; 0 0 1 1 *", false},
+	{"Normal with sub-second duration", "DR=1s500ms;* * * * *", "DR=1s500ms; * * * * *", false},
+	{"Normal with ISO duration", "DR=PT2H30M;* * * * *", "DR=150; * * * * *", false},
+	{"Normal with ISO duration in seconds", "DR=PT90S;* * * * *", "DR=1m30s; * * * * *", false},
+	{"Normal with ISO duration in days", "DR=P1DT4H;TZ=Asia/Tokyo;0 0 1 1 *", "DR=P1DT4H; TZ=Asia/Tokyo; 0 0 1 1 *", false},
+	{"Normal with seconds field", "DR=5; SEC=1; 30 0 9 * * *", "DR=5; SEC=1; 30 0 9 * * *", false},
+	{"Normal with seconds field and time zone", "SEC=true; DR=30s; TZ=Asia/Tokyo; */10 * * * * *", "DR=30s; TZ=Asia/Tokyo; SEC=1; */10 * * * * *", false},
+	{"Normal with disabled seconds flag", "DR=5; SEC=0; 0 9 * * *", "DR=5; 0 9 * * *", false},
+	{"Normal with daily descriptor", "DR=60; @daily", "DR=60; @daily", false},
+	{"Normal with yearly descriptor and time zone", "TZ=Asia/Tokyo; DR=1440; @yearly", "DR=1440; TZ=Asia/Tokyo; @yearly", false},
+	{"Normal with every descriptor", "DR=30;  @every 90m ", "DR=30; @every 90m", false},
+	{"Normal with last day of month", "DR=1440; 0 0 L * *", "DR=1440; 0 0 L * *", false},
+	{"Normal with nth day of week", "DR=60; TZ=Asia/Tokyo; 0 10 ? * MON#2", "DR=60; TZ=Asia/Tokyo; 0 10 ? * MON#2", false},
+	{"Normal with year range", "DR=1440; TZ=America/New_York; 0 0 28 11 * 2025-2027", "DR=1440; TZ=America/New_York; 0 0 28 11 * 2025-2027", false},
+	{"Normal with star year and seconds", "DR=60; SEC=1; 0 0 0 1 1 * *", "DR=60; SEC=1; 0 0 0 1 1 * *", false},
+	{"Normal with question mark year and seconds", "DR=60; SEC=1; 0 0 0 1 1 * ?", "DR=60; SEC=1; 0 0 0 1 1 * ?", false},
+	{"Normal with CRON_TZ prefix", "DR=60; CRON_TZ=Asia/Tokyo 0 9 * * *", "DR=60; CRON_TZ=Asia/Tokyo 0 9 * * *", false},
+	{"Normal with TZ prefix", "DR=60; TZ=Asia/Tokyo 0 9 * * *", "DR=60; TZ=Asia/Tokyo 0 9 * * *", false},
+	{"Normal with CRON_TZ prefix and year", "DR=60; CRON_TZ=Asia/Tokyo 0 9 * * * 2025", "DR=60; CRON_TZ=Asia/Tokyo 0 9 * * * 2025", false},
+	{"Normal with CRON_TZ prefix and seconds", "DR=60; SEC=1; CRON_TZ=Asia/Tokyo 30 0 9 * * *", "DR=60; SEC=1; CRON_TZ=Asia/Tokyo 30 0 9 * * *", false},
+	{"Normal with year and seconds", "DR=1; SEC=1; 30 0 9 * * * 2025,2027", "DR=1; SEC=1; 30 0 9 * * * 2025,2027", false},
+	{"Normal with not-before bound", "DR=5; NB=2025-01-01T00:00:00Z; * * * * *", "DR=5; NB=2025-01-01T00:00:00Z; * * * * *", false},
+	{"Normal with both bounds", "NA=2025-03-31T23:59:59.5+08:00; DR=60; TZ=Asia/Tokyo; NB=2025-01-01T00:00:00+09:00; 0 9 * * *", "DR=60; TZ=Asia/Tokyo; NB=2025-01-01T00:00:00+09:00; NA=2025-03-31T23:59:59.5+08:00; 0 9 * * *", false},
+	{"Normal with count limit", "START=2025-01-06T00:00:00Z; CNT=10; DR=15; 0 9 * * 1", "DR=15; CNT=10; START=2025-01-06T00:00:00Z; 0 9 * * 1", false},
+	{"Normal with start only", "DR=15; START=2025-01-06T00:00:00Z; 0 9 * * 1", "DR=15; START=2025-01-06T00:00:00Z; 0 9 * * 1", false},
+	{"Normal with multiple cron parts", "DR=120; TZ=Europe/Berlin; 0 9 * * 1-5|0 11 * * 0,6", "DR=120; TZ=Europe/Berlin; 0 9 * * 1-5 | 0 11 * * 0,6", false},
+	{"Normal with mixed cron parts", "DR=30;  @every 90m|  0 0 L * * ", "DR=30; @every 90m | 0 0 L * *", false},
+	{"Normal with multiple cron parts and seconds", "DR=5; SEC=1; 30 0 9 * * * | 0 0 12 * * * 2025", "DR=5; SEC=1; 30 0 9 * * * | 0 0 12 * * * 2025", false},
+	{"Normal with exception", "DR=480; EXCEPT=[ DR=1440;0 0 * * 1#1 ]; 0 9 * * 1-5", "DR=480; EXCEPT=[DR=1440; 0 0 * * 1#1]; 0 9 * * 1-5", false},
+	{"Normal with exceptions", "EXCEPT=[DR=60; TZ=Asia/Tokyo; 0 12 * * *]; TZ=Asia/Tokyo; EXCEPT=[DR=P1D; 0 0 1 1 *]; DR=480; 0 9 * * *", "DR=480; TZ=Asia/Tokyo; EXCEPT=[DR=60; TZ=Asia/Tokyo; 0 12 * * *]; EXCEPT=[DR=P1D; 0 0 1 1 *]; 0 9 * * *", false},
+	{"Normal with nested exceptions", "DR=480; EXCEPT=[DR=1440; EXCEPT=[DR=60; 0 0 1 1 *]; 0 0 * * 1]; 0 9 * * *", "DR=480; EXCEPT=[DR=1440; EXCEPT=[DR=60; 0 0 1 1 *]; 0 0 * * 1]; 0 9 * * *", false},
+	{"Normal with date rule", "DR=1440; TZ=Europe/Berlin;  @easter-2 ", "DR=1440; TZ=Europe/Berlin; @easter-2", false},
+	{"Normal with date rules", "DR=4d; TZ=America/New_York; @thanksgiving | @memorial-day-3", "DR=5760; TZ=America/New_York; @thanksgiving | @memorial-day-3", false},
+	{"Normal with interval", "ANCHOR=2025-01-07; DR=60; TZ=Europe/Berlin; EVERY=2w; 0 9 * * 2", "DR=60; TZ=Europe/Berlin; EVERY=2w; ANCHOR=2025-01-07; 0 9 * * 2", false},
+	{"Normal with interval and exception", "DR=60; EXCEPT=[DR=1440; 0 0 1 * *]; EVERY=3d; ANCHOR=2025-01-01; 0 9 * * *", "DR=60; EVERY=3d; ANCHOR=2025-01-01; EXCEPT=[DR=1440; 0 0 1 * *]; 0 9 * * *", false},
+	{"Normal with lead time", "OFF=-30m; DR=45; TZ=Asia/Tokyo; 0 9 * * *", "DR=45; TZ=Asia/Tokyo; OFF=-30; 0 9 * * *", false},
+	{"Normal with delay", "DR=1h; OFF=+2h; 0 0 L * *", "DR=60; OFF=120; 0 0 L * *", false},
+	{"Normal with zero offset", "DR=60; OFF=0; 0 0 L * *", "DR=60; 0 0 L * *", false},
+	{"Normal with closed-open boundary", "BD=closed-open; DR=60; TZ=Etc/UTC; 0 * * * *", "DR=60; TZ=Etc/UTC; BD=closed-open; 0 * * * *", false},
+	{"Normal with open boundary", "DR=60; NB=2025-01-01T00:00:00Z; BD=open; 0 * * * *", "DR=60; BD=open; NB=2025-01-01T00:00:00Z; 0 * * * *", false},
+	{"Normal with default boundary", "DR=60; BD=closed; 0 * * * *", "DR=60; 0 * * * *", false},
+	{"Normal with wall clock DST", "DST=wall; DR=1440; TZ=Europe/Berlin; 0 0 * * *", "DR=1440; TZ=Europe/Berlin; DST=wall; 0 0 * * *", false},
+	{"Normal with wall clock DST and boundary", "DR=60; BD=closed-open; DST=wall; NB=2025-01-01T00:00:00Z; 0 * * * *", "DR=60; BD=closed-open; DST=wall; NB=2025-01-01T00:00:00Z; 0 * * * *", false},
+	{"Normal with default DST mode", "DR=60; DST=abs; 0 * * * *", "DR=60; 0 * * * *", false},
+	{"Normal with largest count", "DR=1; CNT=10000; START=2025-01-06T00:00:00Z; * * * * *", "DR=1; CNT=10000; START=2025-01-06T00:00:00Z; * * * * *", false},
+	{"Normal with zero count", "DR=15; CNT=0; START=2025-01-06T00:00:00Z; 0 9 * * 1", "DR=15; START=2025-01-06T00:00:00Z; 0 9 * * 1", false},
 	{"Normal with complicated expression", "DR=5258765;   TZ=Pacific/Honolulu;   4,8,22,27,33,38,47,50 3,11,14-16,19,21,22 */10 1,3,5,6,9-11 1-5", "DR=5258765; TZ=Pacific/Honolulu; 4,8,22,27,33,38,47,50 3,11,14-16,19,21,22 */10 1,3,5,6,9-11 1-5", false},
 }
 
@@ -105,6 +211,108 @@ func BenchmarkParseString(b *testing.B) {
 	}
 }
 
+func TestParseString_EndExpression(t *testing.T) {
+	tests := []struct {
+		name    string
+		inputS  string
+		wantEnd string
+		wantS   string
+	}{
+		{"Weekend", "END=0 6 * * 1; 0 18 * * 5", "0 6 * * 1", "END=0 6 * * 1; 0 18 * * 5"},
+		{"Weekend in Berlin", "TZ=Europe/Berlin;  END= 0 6 * * MON ;0 18 * * FRI", "0 6 * * MON", "END=0 6 * * MON; TZ=Europe/Berlin; 0 18 * * FRI"},
+		{"Last day with seconds", "SEC=1; END=59 59 23 L * *; 0 0 0 1 * *", "59 59 23 L * *", "END=59 59 23 L * *; SEC=1; 0 0 0 1 * *"},
+		{"Every descriptor", "END=@every 1h; @every 90m", "@every 1h", "END=@every 1h; @every 90m"},
+		{"Multiple end parts", "END=0 12 * * *|0 18 * * *; 0 9,15 * * *", "0 12 * * * | 0 18 * * *", "END=0 12 * * * | 0 18 * * *; 0 9,15 * * *"},
+		{"Business hours", "TZ=Europe/Berlin; Mon-Fri 09:00-17:00", "0 17 * * *", "END=0 17 * * *; TZ=Europe/Berlin; 0 9 * * 1-5"},
+		{"Overnight window", "22:00-06:00", "0 6 * * *", "END=0 6 * * *; 0 22 * * *"},
+		{"Weekend window", "TZ=Europe/Berlin; Sat-Sun 10:00-12:00", "0 12 * * *", "END=0 12 * * *; TZ=Europe/Berlin; 0 10 * * 6,0"},
+		{"Weekend nights window", "TZ=Europe/Berlin; Fri-Sun 22:00-02:00", "0 2 * * *", "END=0 2 * * *; TZ=Europe/Berlin; 0 22 * * 5-6,0"},
+		{"Long weekend nights window", "TZ=Etc/UTC; Fri-Mon 22:00-06:00", "0 6 * * *", "END=0 6 * * *; TZ=Etc/UTC; 0 22 * * 5-6,0-1"},
+		{"Sunday as 7 window", "7 10:00-12:00", "0 12 * * *", "END=0 12 * * *; 0 10 * * 0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotCr, err := ParseString(tt.inputS)
+			if err != nil {
+				t.Errorf("ParseString() error: %v", err)
+				return
+			}
+			if gotCr.EndExpression() != tt.wantEnd || gotCr.Duration() != 0 {
+				t.Errorf("ParseString() got end: %q, duration: %v, want: %q", gotCr.EndExpression(), gotCr.Duration(), tt.wantEnd)
+			}
+			if gotCr.String() != tt.wantS {
+				t.Errorf("ParseString() gotCr: %s, want: %s", gotCr.String(), tt.wantS)
+			}
+		})
+	}
+}
+
+func TestSplitParts(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want []string
+	}{
+		{"Empty", "", []string{""}},
+		{"Plain", "DR=5; * * * * *", []string{"DR=5", " * * * * *"}},
+		{"Brackets", "EXCEPT=[DR=5; 0 * * * *];* * * * *", []string{"EXCEPT=[DR=5; 0 * * * *]", "* * * * *"}},
+		{"Nested brackets", "A=[B=[1;2];3];4", []string{"A=[B=[1;2];3]", "4"}},
+		{"Unmatched closing bracket", "A=];1;2", []string{"A=]", "1", "2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := splitParts(tt.s)
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) || len(got) != len(tt.want) {
+				t.Errorf("splitParts() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCronRange_StringWith(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+		opt  StringOption
+		want string
+	}{
+		{"Default option", "DR=90; * * * * *", 0, "DR=90; * * * * *"},
+		{"ISO option with minutes", "DR=90; * * * * *", ISODuration, "DR=PT1H30M; * * * * *"},
+		{"ISO option with seconds", "DR=90s; TZ=Asia/Tokyo; * * * * *", ISODuration, "DR=PT1M30S; TZ=Asia/Tokyo; * * * * *"},
+		{"ISO option with nominal", "DR=P1M; 0 0 1 * *", ISODuration, "DR=P1M; 0 0 1 * *"},
+		{"Default option with nominal", "DR=P1MT12H; 0 0 1 * *", 0, "DR=1mo12h; 0 0 1 * *"},
+		{"Default option with nominal days", "DR=P1DT4H; 0 0 1 * *", 0, "DR=P1DT4H; 0 0 1 * *"},
+		{"Default option with calendar units", "DR=1y2w; 0 0 1 * *", 0, "DR=1y2w; 0 0 1 * *"},
+		{"ISO option with calendar units", "DR=1y2w3h; 0 0 1 * *", ISODuration, "DR=P1Y14DT3H; 0 0 1 * *"},
+		{"ISO option with offset", "DR=45; OFF=-30m; 0 9 * * *", ISODuration, "DR=PT45M; OFF=-PT30M; 0 9 * * *"},
+		{"Shorthand option", "TZ=Europe/Berlin; mon-fri 9:00-17:00", Shorthand, "TZ=Europe/Berlin; Mon-Fri 09:00-17:00"},
+		{"Shorthand option with end expression", "END=0 6 * * *; 0 22 * * 5,6", Shorthand, "Fri,Sat 22:00-06:00"},
+		{"Shorthand option with range to Sunday", "TZ=Europe/Berlin; Fri-Sun 22:00-02:00", Shorthand, "TZ=Europe/Berlin; Fri-Sun 22:00-02:00"},
+		{"Shorthand option with range wrapping around the week", "TZ=Etc/UTC; Fri-Mon 22:00-06:00", Shorthand, "TZ=Etc/UTC; Fri-Mon 22:00-06:00"},
+		{"Shorthand option with weekend", "Sat-Sun 10:00-12:00", Shorthand, "Sat,Sun 10:00-12:00"},
+		{"Shorthand option with duration", "DR=480; 0 9 * * 1-5", Shorthand, "DR=480; 0 9 * * 1-5"},
+		{"Shorthand option with seconds", "END=0 0 17 * * *; SEC=1; 0 0 9 * * *", Shorthand, "END=0 0 17 * * *; SEC=1; 0 0 9 * * *"},
+		{"Shorthand option with end expression on days", "END=0 6 * * 1; 0 18 * * 5", Shorthand | ISODuration, "END=0 6 * * 1; 0 18 * * 5"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cr, err := ParseString(tt.expr)
+			if err != nil {
+				t.Errorf("ParseString() error: %v", err)
+				return
+			}
+			got := cr.StringWith(tt.opt)
+			if got != tt.want {
+				t.Errorf("StringWith() = %q, want %q", got, tt.want)
+				return
+			}
+			if back, err := ParseString(got); err != nil || back.String() != cr.String() {
+				t.Errorf("ParseString(StringWith()) = %v, %v, want %v", back, err, cr)
+			}
+		})
+	}
+}
+
 func TestCronRange_MarshalJSON(t *testing.T) {
 	tempStructWithPointer := tempTestWithPointer{
 		nil,
@@ -0,0 +1,423 @@
+package cronrange
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	strSetUnion        = `|`
+	strSetIntersection = `&`
+	strSetDifference   = `-`
+
+	errInvalidSetExpr = errors.New("set expression is invalid")
+)
+
+// maxSetSearch is the upper bound of the time span to search for the time ranges of a CronRangeSet, like the five-year limit of schedules,
+// so that it ends up even if the operands never intersect.
+const maxSetSearch = 5 * 366 * 24 * time.Hour
+
+// setOperator is the operator combining two operands of CronRangeSet.
+type setOperator uint8
+
+const (
+	setLeaf setOperator = iota
+	setUnion
+	setIntersection
+	setDifference
+)
+
+// CronRangeSet combines multiple CronRange instances with union, intersection and difference operators.
+type CronRangeSet struct {
+	op          setOperator
+	cr          *CronRange
+	left, right *CronRangeSet
+}
+
+// NewSet returns a CronRangeSet consisting of the given CronRange only, which can be combined with other sets.
+func NewSet(cr *CronRange) *CronRangeSet {
+	return &CronRangeSet{op: setLeaf, cr: cr}
+}
+
+// Union returns a CronRangeSet covering the time ranges of either the set or the other one.
+func (s *CronRangeSet) Union(other *CronRangeSet) *CronRangeSet {
+	return &CronRangeSet{op: setUnion, left: s, right: other}
+}
+
+// Intersection returns a CronRangeSet covering the time ranges of both the set and the other one.
+func (s *CronRangeSet) Intersection(other *CronRangeSet) *CronRangeSet {
+	return &CronRangeSet{op: setIntersection, left: s, right: other}
+}
+
+// Difference returns a CronRangeSet covering the time ranges of the set but not the other one.
+func (s *CronRangeSet) Difference(other *CronRangeSet) *CronRangeSet {
+	return &CronRangeSet{op: setDifference, left: s, right: other}
+}
+
+func (s *CronRangeSet) checkPrecondition() {
+	switch {
+	case s == nil:
+		panic("CronRangeSet is nil")
+	case s.op == setLeaf:
+		s.cr.checkPrecondition()
+	default:
+		s.left.checkPrecondition()
+		s.right.checkPrecondition()
+	}
+}
+
+// IsWithin checks if the given time falls within the time ranges represented by the set.
+//
+// It panics if the CronRangeSet or any CronRange in it is nil or incomplete.
+func (s *CronRangeSet) IsWithin(t time.Time) bool {
+	s.checkPrecondition()
+	return s.isWithin(t)
+}
+
+func (s *CronRangeSet) isWithin(t time.Time) bool {
+	switch s.op {
+	case setUnion:
+		return s.left.isWithin(t) || s.right.isWithin(t)
+	case setIntersection:
+		return s.left.isWithin(t) && s.right.isWithin(t)
+	case setDifference:
+		return s.left.isWithin(t) && !s.right.isWithin(t)
+	default:
+		return s.cr.IsWithin(t)
+	}
+}
+
+// NextOccurrences returns the next time ranges of the set starting later than the given time,
+// the overlapping or adjoining time ranges are merged, so the returned ones are in chronological order and never overlap.
+//
+// Time ranges starting more than five years after the given time are not searched, so fewer ones may be returned.
+//
+// It panics if count is less than one, or the CronRangeSet or any CronRange in it is nil or incomplete.
+func (s *CronRangeSet) NextOccurrences(t time.Time, count int) (occurs []TimeRange) {
+	s.checkPrecondition()
+	if count <= 0 {
+		panic("count is not positive")
+	}
+
+	next := s.iterate(t, t.Add(maxSetSearch))
+	for len(occurs) < count {
+		occur, ok := next()
+		if !ok {
+			break
+		}
+		if occur.Start.After(t) {
+			occurs = append(occurs, occur)
+		}
+	}
+	return
+}
+
+// rangeIter returns the next time range in chronological order each time it's called, or false if there's no more.
+type rangeIter func() (TimeRange, bool)
+
+// iterate returns the merged time ranges of the set, including the one running across the given time, and up to the ones starting at the horizon.
+func (s *CronRangeSet) iterate(t, horizon time.Time) rangeIter {
+	switch s.op {
+	case setUnion:
+		return unionRanges(s.left.iterate(t, horizon), s.right.iterate(t, horizon))
+	case setIntersection:
+		return intersectRanges(s.left.iterate(t, horizon), s.right.iterate(t, horizon))
+	case setDifference:
+		return subtractRanges(s.left.iterate(t, horizon), s.right.iterate(t, horizon))
+	default:
+		return mergeRanges(s.cr.iterate(t, horizon))
+	}
+}
+
+// iterate returns the time ranges of the CronRange, including the one running across the given time, and up to the ones starting at the horizon.
+func (cr *CronRange) iterate(t, horizon time.Time) rangeIter {
+	curr := t
+	if start, found := lastActivation(cr.schedule, t, cr.maxLength()+1*time.Second); found {
+		curr = start.Add(-time.Nanosecond)
+	}
+
+	// the iterator keeps the rest of the time ranges split by the exceptions
+	it, done := cr.Iterator(curr), false
+	return func() (tr TimeRange, ok bool) {
+		if done {
+			return
+		}
+		if tr, ok = it.Next(); !ok || tr.Start.After(horizon) {
+			done = true
+			return TimeRange{}, false
+		}
+		return
+	}
+}
+
+// mergeRanges merges the overlapping or adjoining time ranges sorted by the starting time.
+func mergeRanges(next rangeIter) rangeIter {
+	pending, hasPending := next()
+	return func() (tr TimeRange, ok bool) {
+		if !hasPending {
+			return
+		}
+		tr, ok = pending, true
+		for {
+			if pending, hasPending = next(); !hasPending || pending.Start.After(tr.End) {
+				return
+			}
+			if pending.End.After(tr.End) {
+				tr.End = pending.End
+			}
+		}
+	}
+}
+
+// unionRanges returns the merged time ranges of either of the two sequences.
+func unionRanges(a, b rangeIter) rangeIter {
+	ra, okA := a()
+	rb, okB := b()
+	return mergeRanges(func() (tr TimeRange, ok bool) {
+		switch {
+		case okA && (!okB || !rb.Start.Before(ra.Start)):
+			tr, ok = ra, true
+			ra, okA = a()
+		case okB:
+			tr, ok = rb, true
+			rb, okB = b()
+		}
+		return
+	})
+}
+
+// intersectRanges returns the time ranges covered by both of the two merged sequences, and drops the empty ones.
+func intersectRanges(a, b rangeIter) rangeIter {
+	ra, okA := a()
+	rb, okB := b()
+	return func() (tr TimeRange, ok bool) {
+		for okA && okB {
+			tr = TimeRange{Start: ra.Start, End: ra.End}
+			if rb.Start.After(tr.Start) {
+				tr.Start = rb.Start
+			}
+			if rb.End.Before(tr.End) {
+				tr.End = rb.End
+			}
+
+			// the one ending first won't intersect any more
+			if ra.End.Before(rb.End) {
+				ra, okA = a()
+			} else {
+				rb, okB = b()
+			}
+			if tr.Start.Before(tr.End) {
+				return tr, true
+			}
+		}
+		return TimeRange{}, false
+	}
+}
+
+// subtractRanges returns the time ranges covered by the first merged sequence but not the second one.
+func subtractRanges(a, b rangeIter) rangeIter {
+	ra, okA := a()
+	rb, okB := b()
+	return func() (tr TimeRange, ok bool) {
+		for okA {
+			// skip the subtrahends ending before the current time range
+			for okB && !rb.End.After(ra.Start) {
+				rb, okB = b()
+			}
+			if !okB || !rb.Start.Before(ra.End) {
+				tr, ok = ra, true
+				ra, okA = a()
+				return
+			}
+
+			// the subtrahend overlaps, so keep the part before it, and continue with the part after it
+			if ra.Start.Before(rb.Start) {
+				tr, ok = TimeRange{Start: ra.Start, End: rb.Start}, true
+			}
+			if rb.End.Before(ra.End) {
+				ra.Start = rb.End
+			} else {
+				ra, okA = a()
+			}
+			if ok {
+				return
+			}
+		}
+		return
+	}
+}
+
+// String returns a normalized CronRangeSet expression, which can be consumed by ParseSetString().
+// Each CronRange is enclosed in square brackets, and nested sets on the right side of operators are enclosed in parentheses.
+func (s CronRangeSet) String() string {
+	sb := strings.Builder{}
+	s.writeTo(&sb)
+	return sb.String()
+}
+
+func (s *CronRangeSet) writeTo(sb *strings.Builder) {
+	var op string
+	switch s.op {
+	case setUnion:
+		op = strSetUnion
+	case setIntersection:
+		op = strSetIntersection
+	case setDifference:
+		op = strSetDifference
+	default:
+		sb.WriteString("[")
+		if s.cr != nil {
+			sb.WriteString(s.cr.String())
+		}
+		sb.WriteString("]")
+		return
+	}
+
+	s.left.writeTo(sb)
+	sb.WriteString(strSingleWhitespace)
+	sb.WriteString(op)
+	sb.WriteString(strSingleWhitespace)
+	if s.right.op != setLeaf {
+		sb.WriteString("(")
+		s.right.writeTo(sb)
+		sb.WriteString(")")
+	} else {
+		s.right.writeTo(sb)
+	}
+}
+
+// ParseSetString attempts to deserialize the given CronRangeSet expression or return failure if any parsing errors occur.
+//
+// Each CronRange expression is enclosed in square brackets, and combined with the operators '|' for union, '&' for intersection and '-' for difference,
+// e.g. "[DR=480; TZ=Europe/Berlin; 0 9 * * 1-5] - [DR=60; TZ=Europe/Berlin; 0 12 * * *]" stands for the business hours except the lunch break.
+// The operators have the same precedence and are evaluated from left to right, and parentheses can be used for grouping.
+func ParseSetString(s string) (set *CronRangeSet, err error) {
+	if strings.TrimSpace(s) == "" {
+		err = errEmptyExpr
+		return
+	}
+
+	p := setParser{expr: s}
+	if set, err = p.parseSet(); err == nil && p.pos < len(s) {
+		// unmatched closing parenthesis
+		set, err = nil, fmt.Errorf("%w: %q", errInvalidSetExpr, s)
+	}
+	return
+}
+
+// setParser parses the CronRangeSet expression by recursive descent.
+type setParser struct {
+	expr string
+	pos  int
+}
+
+func (p *setParser) skipSpaces() {
+	for p.pos < len(p.expr) && (p.expr[p.pos] == ' ' || p.expr[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+// parseSet parses the operands and operators until the end of expression or a closing parenthesis.
+func (p *setParser) parseSet() (set *CronRangeSet, err error) {
+	if set, err = p.parseOperand(); err != nil {
+		return
+	}
+	for {
+		if p.skipSpaces(); p.pos >= len(p.expr) || p.expr[p.pos] == ')' {
+			return
+		}
+
+		var op setOperator
+		switch p.expr[p.pos : p.pos+1] {
+		case strSetUnion:
+			op = setUnion
+		case strSetIntersection:
+			op = setIntersection
+		case strSetDifference:
+			op = setDifference
+		default:
+			return nil, fmt.Errorf("%w: unexpected %q at %d", errInvalidSetExpr, p.expr[p.pos], p.pos)
+		}
+		p.pos++
+
+		var right *CronRangeSet
+		if right, err = p.parseOperand(); err != nil {
+			return nil, err
+		}
+		set = &CronRangeSet{op: op, left: set, right: right}
+	}
+}
+
+// parseOperand parses either a CronRange expression in square brackets, or a nested set in parentheses.
+func (p *setParser) parseOperand() (set *CronRangeSet, err error) {
+	p.skipSpaces()
+	if p.pos >= len(p.expr) {
+		return nil, fmt.Errorf("%w: missing operand at %d", errInvalidSetExpr, p.pos)
+	}
+
+	switch p.expr[p.pos] {
+	case '[':
+		end := closingBracket(p.expr[p.pos:])
+		if end < 0 {
+			return nil, fmt.Errorf("%w: unclosed bracket at %d", errInvalidSetExpr, p.pos)
+		}
+		var cr *CronRange
+		if cr, err = ParseString(p.expr[p.pos+1 : p.pos+end]); err != nil {
+			return nil, err
+		}
+		p.pos += end + 1
+		set = NewSet(cr)
+	case '(':
+		start := p.pos
+		p.pos++
+		if set, err = p.parseSet(); err != nil {
+			return nil, err
+		}
+		if p.pos >= len(p.expr) {
+			return nil, fmt.Errorf("%w: unclosed parenthesis at %d", errInvalidSetExpr, start)
+		}
+		p.pos++
+	default:
+		return nil, fmt.Errorf("%w: unexpected %q at %d", errInvalidSetExpr, p.expr[p.pos], p.pos)
+	}
+	return
+}
+
+// closingBracket returns the index of the square bracket closing the one at the beginning of the string, or -1 if it's unclosed,
+// the nested brackets of EXCEPT= parts are skipped.
+func closingBracket(s string) int {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '[':
+			depth++
+		case ']':
+			if depth--; depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// MarshalJSON implements the encoding/json.Marshaler interface for serialization of CronRangeSet.
+func (s CronRangeSet) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// UnmarshalJSON implements the encoding/json.Unmarshaler interface for deserialization of CronRangeSet.
+func (s *CronRangeSet) UnmarshalJSON(b []byte) (err error) {
+	var expr string
+	if err = json.Unmarshal(b, &expr); err != nil {
+		return
+	}
+
+	var newSet *CronRangeSet
+	if newSet, err = ParseSetString(expr); err == nil {
+		*s = *newSet
+	}
+	return
+}
@@ -0,0 +1,301 @@
+package cronrange
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func setMustParse(s string) *CronRangeSet {
+	set, err := ParseSetString(s)
+	if err != nil {
+		panic(err)
+	}
+	return set
+}
+
+var (
+	exprSetBusinessHours = "[DR=480; TZ=Etc/UTC; 0 9 * * 1-5]"
+	exprSetLunchBreak    = "[DR=60; TZ=Etc/UTC; 0 12 * * *]"
+	exprSetWeekend       = "[DR=1440; TZ=Etc/UTC; 0 0 * * 0,6]"
+	exprSetMornings      = "[DR=360; TZ=Etc/UTC; 0 6 * * *]"
+
+	exprSetBusinessHoursExceptLunch = "[DR=480; TZ=Etc/UTC; EXCEPT=[DR=60; TZ=Etc/UTC; 0 12 * * *]; 0 9 * * *]"
+)
+
+func TestParseSetString(t *testing.T) {
+	tests := []struct {
+		name    string
+		inputS  string
+		wantS   string
+		wantErr bool
+	}{
+		{"Empty string", emptyString, emptyString, true},
+		{"Blank string", "   ", emptyString, true},
+		{"Missing bracket", "DR=5; * * * * *", emptyString, true},
+		{"Unclosed bracket", "[DR=5; * * * * *", emptyString, true},
+		{"Unclosed bracket with exception", "[DR=480; EXCEPT=[DR=60; 0 12 * * *]; 0 9 * * *", emptyString, true},
+		{"Invalid cron range", "[DR=0; * * * * *]", emptyString, true},
+		{"Missing operand", "[DR=5; * * * * *] |", emptyString, true},
+		{"Missing operator", "[DR=5; * * * * *] [DR=5; 0 * * * *]", emptyString, true},
+		{"Unknown operator", "[DR=5; * * * * *] + [DR=5; 0 * * * *]", emptyString, true},
+		{"Unclosed parenthesis", "[DR=5; * * * * *] | ([DR=5; 0 * * * *]", emptyString, true},
+		{"Unmatched parenthesis", "[DR=5; * * * * *] | [DR=5; 0 * * * *])", emptyString, true},
+		{"Empty parentheses", "()", emptyString, true},
+		{"Single", " [ DR=5;* * * * * ] ", "[DR=5; * * * * *]", false},
+		{"Single in parentheses", "([DR=5; * * * * *])", "[DR=5; * * * * *]", false},
+		{"Union", exprSetBusinessHours + "|" + exprSetWeekend, exprSetBusinessHours + " | " + exprSetWeekend, false},
+		{"Intersection", exprSetBusinessHours + "&" + exprSetMornings, exprSetBusinessHours + " & " + exprSetMornings, false},
+		{"Difference", exprSetBusinessHours + "\t-\t" + exprSetLunchBreak, exprSetBusinessHours + " - " + exprSetLunchBreak, false},
+		{"Left to right", exprSetBusinessHours + " | " + exprSetWeekend + " - " + exprSetLunchBreak, exprSetBusinessHours + " | " + exprSetWeekend + " - " + exprSetLunchBreak, false},
+		{"Redundant parentheses", "(" + exprSetBusinessHours + " | " + exprSetWeekend + ") - " + exprSetLunchBreak, exprSetBusinessHours + " | " + exprSetWeekend + " - " + exprSetLunchBreak, false},
+		{"Nested on right", exprSetBusinessHours + " - (" + exprSetLunchBreak + " & " + exprSetMornings + ")", exprSetBusinessHours + " - (" + exprSetLunchBreak + " & " + exprSetMornings + ")", false},
+		{"With exception", exprSetBusinessHoursExceptLunch + " | " + exprSetWeekend, exprSetBusinessHoursExceptLunch + " | " + exprSetWeekend, false},
+		{"With exception in parentheses", "(" + exprSetBusinessHoursExceptLunch + ") - " + exprSetMornings, exprSetBusinessHoursExceptLunch + " - " + exprSetMornings, false},
+		{"Multiple cron parts", "[DR=120; 0 9 * * 1-5 | 0 11 * * 0,6] - [DR=5; 0 10 * * *]", "[DR=120; 0 9 * * 1-5 | 0 11 * * 0,6] - [DR=5; 0 10 * * *]", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSetString(tt.inputS)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseSetString() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.String() != tt.wantS {
+				t.Errorf("ParseSetString() got = %s, want %s", got, tt.wantS)
+			}
+		})
+	}
+}
+
+func BenchmarkParseSetString(b *testing.B) {
+	expr := exprSetBusinessHours + " | " + exprSetWeekend + " - " + exprSetLunchBreak
+	for i := 0; i < b.N; i++ {
+		_, _ = ParseSetString(expr)
+	}
+}
+
+func TestCronRangeSet_Operators(t *testing.T) {
+	business, lunch := NewSet(crMustParse(exprSetBusinessHours[1:len(exprSetBusinessHours)-1])), NewSet(crMustParse(exprSetLunchBreak[1:len(exprSetLunchBreak)-1]))
+	tests := []struct {
+		name string
+		set  *CronRangeSet
+		want string
+	}{
+		{"Union", business.Union(lunch), exprSetBusinessHours + " | " + exprSetLunchBreak},
+		{"Intersection", business.Intersection(lunch), exprSetBusinessHours + " & " + exprSetLunchBreak},
+		{"Difference", business.Difference(lunch), exprSetBusinessHours + " - " + exprSetLunchBreak},
+		{"Nested", lunch.Difference(business.Union(lunch)), exprSetLunchBreak + " - (" + exprSetBusinessHours + " | " + exprSetLunchBreak + ")"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.set.String(); got != tt.want {
+				t.Errorf("String() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCronRangeSet_IsWithin(t *testing.T) {
+	tests := []struct {
+		name       string
+		set        *CronRangeSet
+		t          time.Time
+		wantWithin bool
+		wantPanic  bool
+	}{
+		{"Nil set", nil, firstSec2020Utc, false, true},
+		{"Nil cron range", NewSet(nil), firstSec2020Utc, false, true},
+		{"Incomplete cron range", NewSet(crEmpty).Union(NewSet(crEvery1Min)), firstSec2020Utc, false, true},
+		{"Union - first", setMustParse(exprSetBusinessHours + " | " + exprSetWeekend), parseTime(locationUTC, "2020-01-03 10:00:00"), true, false},
+		{"Union - second", setMustParse(exprSetBusinessHours + " | " + exprSetWeekend), parseTime(locationUTC, "2020-01-04 20:00:00"), true, false},
+		{"Union - neither", setMustParse(exprSetBusinessHours + " | " + exprSetWeekend), parseTime(locationUTC, "2020-01-03 20:00:00"), false, false},
+		{"Intersection - both", setMustParse(exprSetBusinessHours + " & " + exprSetMornings), parseTime(locationUTC, "2020-01-03 11:00:00"), true, false},
+		{"Intersection - first only", setMustParse(exprSetBusinessHours + " & " + exprSetMornings), parseTime(locationUTC, "2020-01-03 13:00:00"), false, false},
+		{"Difference - first only", setMustParse(exprSetBusinessHours + " - " + exprSetLunchBreak), parseTime(locationUTC, "2020-01-03 11:59:59"), true, false},
+		{"Difference - both", setMustParse(exprSetBusinessHours + " - " + exprSetLunchBreak), parseTime(locationUTC, "2020-01-03 12:30:00"), false, false},
+		{"Difference - second only", setMustParse(exprSetBusinessHours + " - " + exprSetLunchBreak), parseTime(locationUTC, "2020-01-04 12:30:00"), false, false},
+		{"Nested", setMustParse(exprSetBusinessHours + " | " + exprSetWeekend + " - " + exprSetLunchBreak), parseTime(locationUTC, "2020-01-04 12:30:00"), false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); (r != nil) != tt.wantPanic {
+					t.Errorf("IsWithin() panic = %v, wantPanic %v", r, tt.wantPanic)
+				}
+			}()
+			if got := tt.set.IsWithin(tt.t); got != tt.wantWithin {
+				t.Errorf("IsWithin() = %v, want %v", got, tt.wantWithin)
+			}
+		})
+	}
+}
+
+func BenchmarkCronRangeSet_IsWithin(b *testing.B) {
+	set := setMustParse(exprSetBusinessHours + " | " + exprSetWeekend + " - " + exprSetLunchBreak)
+	t := parseTime(locationUTC, "2020-01-03 12:30:00")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = set.IsWithin(t)
+	}
+}
+
+func TestCronRangeSet_NextOccurrences(t *testing.T) {
+	tests := []struct {
+		name      string
+		set       *CronRangeSet
+		t         time.Time
+		count     int
+		want      []TimeRange
+		wantPanic bool
+	}{
+		{"Nil set", nil, firstSec2020Utc, 1, nil, true},
+		{"Zero count", setMustParse(exprSetBusinessHours), firstSec2020Utc, 0, nil, true},
+		{"Single", setMustParse(exprSetLunchBreak), firstSec2020Utc, 2,
+			[]TimeRange{
+				{parseTime(locationUTC, "2020-01-01 12:00:00"), parseTime(locationUTC, "2020-01-01 13:00:00")},
+				{parseTime(locationUTC, "2020-01-02 12:00:00"), parseTime(locationUTC, "2020-01-02 13:00:00")},
+			},
+			false,
+		},
+		{"Single with endless overlaps", NewSet(crEveryDayWithOverlap), firstSec2020Utc.In(time.Local), 1, nil, false},
+		{"Union merges adjoining", setMustParse(exprSetMornings + " | " + exprSetBusinessHours), parseTime(locationUTC, "2020-01-03 01:00:00"), 3,
+			[]TimeRange{
+				{parseTime(locationUTC, "2020-01-03 06:00:00"), parseTime(locationUTC, "2020-01-03 17:00:00")},
+				{parseTime(locationUTC, "2020-01-04 06:00:00"), parseTime(locationUTC, "2020-01-04 12:00:00")},
+				{parseTime(locationUTC, "2020-01-05 06:00:00"), parseTime(locationUTC, "2020-01-05 12:00:00")},
+			},
+			false,
+		},
+		{"Union with the weekend", setMustParse(exprSetBusinessHours + " | " + exprSetWeekend), parseTime(locationUTC, "2020-01-02 10:00:00"), 3,
+			[]TimeRange{
+				{parseTime(locationUTC, "2020-01-03 09:00:00"), parseTime(locationUTC, "2020-01-03 17:00:00")},
+				{parseTime(locationUTC, "2020-01-04 00:00:00"), parseTime(locationUTC, "2020-01-06 00:00:00")},
+				{parseTime(locationUTC, "2020-01-06 09:00:00"), parseTime(locationUTC, "2020-01-06 17:00:00")},
+			},
+			false,
+		},
+		{"Intersection", setMustParse(exprSetBusinessHours + " & " + exprSetMornings), parseTime(locationUTC, "2020-01-02 10:00:00"), 2,
+			[]TimeRange{
+				{parseTime(locationUTC, "2020-01-03 09:00:00"), parseTime(locationUTC, "2020-01-03 12:00:00")},
+				{parseTime(locationUTC, "2020-01-06 09:00:00"), parseTime(locationUTC, "2020-01-06 12:00:00")},
+			},
+			false,
+		},
+		{"Intersection never happens", setMustParse("[DR=60; TZ=Etc/UTC; 0 0 * * 1] & [DR=60; TZ=Etc/UTC; 0 0 * * 2]"), firstSec2020Utc, 2, nil, false},
+		{"Difference splits ranges", setMustParse(exprSetBusinessHours + " - " + exprSetLunchBreak), parseTime(locationUTC, "2020-01-03 10:00:00"), 3,
+			[]TimeRange{
+				{parseTime(locationUTC, "2020-01-03 13:00:00"), parseTime(locationUTC, "2020-01-03 17:00:00")},
+				{parseTime(locationUTC, "2020-01-06 09:00:00"), parseTime(locationUTC, "2020-01-06 12:00:00")},
+				{parseTime(locationUTC, "2020-01-06 13:00:00"), parseTime(locationUTC, "2020-01-06 17:00:00")},
+			},
+			false,
+		},
+		{"Difference covers whole ranges", setMustParse(exprSetLunchBreak + " - " + exprSetBusinessHours), parseTime(locationUTC, "2020-01-03 10:00:00"), 2,
+			[]TimeRange{
+				{parseTime(locationUTC, "2020-01-04 12:00:00"), parseTime(locationUTC, "2020-01-04 13:00:00")},
+				{parseTime(locationUTC, "2020-01-05 12:00:00"), parseTime(locationUTC, "2020-01-05 13:00:00")},
+			},
+			false,
+		},
+		{"Nested", setMustParse(exprSetBusinessHours + " | " + exprSetWeekend + " - " + exprSetLunchBreak), parseTime(locationUTC, "2020-01-03 14:00:00"), 3,
+			[]TimeRange{
+				{parseTime(locationUTC, "2020-01-04 00:00:00"), parseTime(locationUTC, "2020-01-04 12:00:00")},
+				{parseTime(locationUTC, "2020-01-04 13:00:00"), parseTime(locationUTC, "2020-01-05 12:00:00")},
+				{parseTime(locationUTC, "2020-01-05 13:00:00"), parseTime(locationUTC, "2020-01-06 00:00:00")},
+			},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); (r != nil) != tt.wantPanic {
+					t.Errorf("NextOccurrences() panic = %v, wantPanic %v", r, tt.wantPanic)
+				}
+			}()
+			got := tt.set.NextOccurrences(tt.t, tt.count)
+			if !isTimeRangeSliceEqual(got, tt.want) {
+				t.Errorf("NextOccurrences() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func BenchmarkCronRangeSet_NextOccurrences(b *testing.B) {
+	set := setMustParse(exprSetBusinessHours + " | " + exprSetWeekend + " - " + exprSetLunchBreak)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = set.NextOccurrences(firstSec2020Utc, 10)
+	}
+}
+
+func TestCronRangeSet_NextOccurrences_SplitByExceptions(t *testing.T) {
+	// each activation is split into many parts, more than a batch of the iteration
+	cr := crMustParse("DR=480; TZ=Etc/UTC; EXCEPT=[DR=5; TZ=Etc/UTC; */20 * * * *]; 0 9 * * *")
+	from := parseTime(locationUTC, "2025-01-01 00:00:00")
+	want := cr.NextOccurrences(from, 40)
+	if got := NewSet(cr).NextOccurrences(from, 40); !isTimeRangeSliceEqual(got, want) {
+		t.Errorf("NextOccurrences() got = %v, want %v", got, want)
+	}
+
+	// and so are the nested exceptions
+	nested := crMustParse("DR=1440; TZ=Etc/UTC; EXCEPT=[DR=480; TZ=Etc/UTC; EXCEPT=[DR=5; TZ=Etc/UTC; */20 * * * *]; 0 9 * * *]; 0 0 * * *")
+	wantNested := []TimeRange{
+		{parseTime(locationUTC, "2025-01-02 00:00:00"), parseTime(locationUTC, "2025-01-02 09:05:00")},
+	}
+	for at := parseTime(locationUTC, "2025-01-02 09:20:00"); at.Hour() < 17; at = at.Add(20 * time.Minute) {
+		// the parts excluded from the exception are left in the gaps of it
+		wantNested = append(wantNested, TimeRange{at, at.Add(5 * time.Minute)})
+	}
+	wantNested = append(wantNested, TimeRange{parseTime(locationUTC, "2025-01-02 17:00:00"), parseTime(locationUTC, "2025-01-03 00:00:00")})
+	if got := nested.NextOccurrences(from, len(wantNested)); !isTimeRangeSliceEqual(got, wantNested) {
+		t.Errorf("NextOccurrences() of nested got = %v, want %v", got, wantNested)
+	}
+}
+
+func TestCronRangeSet_JSON(t *testing.T) {
+	type holder struct {
+		Set  *CronRangeSet
+		Name string
+	}
+	expr := exprSetBusinessHours + " - (" + exprSetLunchBreak + " | " + exprSetWeekend + ")"
+	b, err := json.Marshal(holder{setMustParse(expr), "Office"})
+	if err != nil {
+		t.Errorf("MarshalJSON() error = %v", err)
+		return
+	}
+	if want := `{"Set":"` + expr + `","Name":"Office"}`; string(b) != want {
+		t.Errorf("MarshalJSON() got = %s, want %s", b, want)
+	}
+
+	var got holder
+	if err = json.Unmarshal(b, &got); err != nil {
+		t.Errorf("UnmarshalJSON() error = %v", err)
+		return
+	}
+	if got.Set.String() != expr || got.Name != "Office" {
+		t.Errorf("UnmarshalJSON() got = %v, want %s", got, expr)
+	}
+
+	// the member with exceptions round-trips too
+	exceptExpr := exprSetBusinessHoursExceptLunch + " & " + exprSetMornings
+	if b, err = json.Marshal(NewSet(crMustParse(exprSetBusinessHoursExceptLunch[1 : len(exprSetBusinessHoursExceptLunch)-1])).Intersection(setMustParse(exprSetMornings))); err != nil {
+		t.Errorf("MarshalJSON() error = %v", err)
+		return
+	}
+	var gotSet CronRangeSet
+	if err = json.Unmarshal(b, &gotSet); err != nil {
+		t.Errorf("UnmarshalJSON() error = %v", err)
+		return
+	}
+	if gotSet.String() != exceptExpr {
+		t.Errorf("UnmarshalJSON() got = %s, want %s", gotSet.String(), exceptExpr)
+	}
+
+	for _, broken := range []string{`{"Set":""}`, `{"Set":"[DR=5; * * * * *"}`, `{"Set":5}`} {
+		if err = json.Unmarshal([]byte(broken), &got); err == nil {
+			t.Errorf("UnmarshalJSON() of %s got no error", broken)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package cronrange
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	errInvalidWindow      = errors.New("time window is invalid")
+	errWindowWithDuration = errors.New("time window should not be set along with duration, end expression or seconds field")
+
+	dowShortNames = [...]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"}
+)
+
+// isTimeWindow checks if the expression looks like a wall-clock time window, since cron expressions never contain colons.
+func isTimeWindow(s string) bool {
+	return strings.Contains(s, ":")
+}
+
+// parseTimeWindow parses the wall-clock shorthand like "Mon-Fri 09:00-17:00" or "22:00-06:00" into cron expressions for the starting and ending times.
+// The days of week are optional and can be names like "Mon" or numbers from 0 (Sunday) to 7 (Sunday again), in lists and ranges like "Mon-Fri,Sun" and "Fri-Mon" wrapping around the week,
+// and the window runs overnight if the ending time is not after the starting time. The ending time can be "24:00" for the midnight.
+func parseTimeWindow(s string) (startExpr, endExpr string, err error) {
+	invalid := fmt.Errorf("%w: %q", errInvalidWindow, s)
+
+	var dowField, clockField string
+	switch fields := strings.Fields(s); len(fields) {
+	case 1:
+		dowField, clockField = "*", fields[0]
+	case 2:
+		if dowField, err = parseWindowDays(fields[0]); err != nil {
+			err = invalid
+			return
+		}
+		clockField = fields[1]
+	default:
+		err = invalid
+		return
+	}
+
+	clocks := strings.Split(clockField, "-")
+	if len(clocks) != 2 {
+		err = invalid
+		return
+	}
+	var startHour, startMin, endHour, endMin int
+	if startHour, startMin, err = parseWindowClock(clocks[0], false); err != nil {
+		err = invalid
+		return
+	}
+	if endHour, endMin, err = parseWindowClock(clocks[1], true); err != nil {
+		err = invalid
+		return
+	}
+
+	startExpr = fmt.Sprintf("%d %d * * %s", startMin, startHour, dowField)
+	endExpr = fmt.Sprintf("%d %d * * *", endMin, endHour%24)
+	return
+}
+
+// parseWindowClock parses the wall-clock time like "09:00", and "24:00" is only allowed for the ending time.
+func parseWindowClock(s string, isEnd bool) (hour, min int, err error) {
+	idx := strings.Index(s, ":")
+	if idx < 1 || idx > 2 || len(s)-idx != 3 {
+		err = errInvalidWindow
+		return
+	}
+	if hour, err = strconv.Atoi(s[:idx]); err != nil {
+		return
+	}
+	if min, err = strconv.Atoi(s[idx+1:]); err != nil {
+		return
+	}
+	if hour < 0 || min < 0 || min > 59 || hour > 24 || (hour == 24 && (!isEnd || min > 0)) {
+		err = errInvalidWindow
+	}
+	return
+}
+
+// parseWindowDays converts the days of week in the time window into the day-of-week field of cron expression, e.g. "Mon-Fri" to "1-5".
+func parseWindowDays(s string) (field string, err error) {
+	items := strings.Split(s, ",")
+	for i, item := range items {
+		bounds := strings.Split(item, "-")
+		if len(bounds) > 2 {
+			return "", errInvalidWindow
+		}
+		days := make([]int, len(bounds))
+		for j, b := range bounds {
+			n, ok := dowNames[strings.ToLower(b)]
+			if !ok {
+				var e error
+				if n, e = strconv.Atoi(b); e != nil || n < 0 || n > 7 {
+					return "", errInvalidWindow
+				}
+			}
+			// the cron parser only takes 0 for Sunday
+			days[j] = n % 7
+		}
+
+		switch {
+		case len(days) == 1:
+			items[i] = strconv.Itoa(days[0])
+		case days[0] > days[1]:
+			// ranges like "Fri-Mon" wrap around the week, and are split into "5-6,0-1", since Sunday comes first in the cron field
+			items[i] = windowDayRange(days[0], 6) + "," + windowDayRange(0, days[1])
+		default:
+			items[i] = windowDayRange(days[0], days[1])
+		}
+	}
+	return strings.Join(items, ","), nil
+}
+
+// windowDayRange returns the range of days of week in cron expression, or the single day if both ends are the same.
+func windowDayRange(low, high int) string {
+	if low == high {
+		return strconv.Itoa(low)
+	}
+	return fmt.Sprintf("%d-%d", low, high)
+}
+
+// formatTimeWindow returns the wall-clock shorthand for the cron expressions of the starting and ending times,
+// or false if they're not representable in the shorthand.
+func formatTimeWindow(startExpr, endExpr string) (s string, ok bool) {
+	startFields, endFields := strings.Fields(startExpr), strings.Fields(endExpr)
+	if len(startFields) != 5 || len(endFields) != 5 || startFields[2] != "*" || startFields[3] != "*" ||
+		endFields[2] != "*" || endFields[3] != "*" || endFields[4] != "*" {
+		return
+	}
+
+	var startClock, endClock string
+	if startClock, ok = formatWindowClock(startFields[1], startFields[0]); !ok {
+		return
+	}
+	if endClock, ok = formatWindowClock(endFields[1], endFields[0]); !ok {
+		return
+	}
+
+	sb := strings.Builder{}
+	if dowField := startFields[4]; dowField != "*" {
+		var days string
+		if days, ok = formatWindowDays(dowField); !ok {
+			return
+		}
+		sb.WriteString(days)
+		sb.WriteString(strSingleWhitespace)
+	}
+	sb.WriteString(startClock)
+	sb.WriteString("-")
+	sb.WriteString(endClock)
+	return sb.String(), true
+}
+
+// formatWindowClock returns the wall-clock time like "09:00" for the plain hour and minute fields of cron expression.
+func formatWindowClock(hourField, minField string) (s string, ok bool) {
+	hour, err := strconv.Atoi(hourField)
+	if err != nil || hour < 0 || hour > 23 {
+		return
+	}
+	min, err := strconv.Atoi(minField)
+	if err != nil || min < 0 || min > 59 {
+		return
+	}
+	return fmt.Sprintf("%02d:%02d", hour, min), true
+}
+
+// formatWindowDays converts the day-of-week field of cron expression into the days of week in the time window, e.g. "1-5" to "Mon-Fri".
+func formatWindowDays(field string) (s string, ok bool) {
+	items := strings.Split(field, ",")
+	for i, item := range items {
+		bounds := strings.Split(item, "-")
+		if len(bounds) > 2 {
+			return
+		}
+		for j, b := range bounds {
+			n, found := dowNames[strings.ToLower(b)]
+			if !found {
+				var err error
+				if n, err = strconv.Atoi(b); err != nil || n < 0 || n > 7 {
+					return
+				}
+			}
+			bounds[j] = dowShortNames[n%7]
+		}
+		items[i] = strings.Join(bounds, "-")
+	}
+	// merge the split ranges like "Fri-Sat,Sun" and "Fri-Sat,Sun-Mon" back into "Fri-Sun" and "Fri-Mon"
+	s = strings.Replace(strings.Join(items, ","), "-Sat,Sun-", "-", 1)
+	if strings.HasSuffix(s, "-Sat,Sun") {
+		s = strings.TrimSuffix(s, "-Sat,Sun") + "-Sun"
+	}
+	return s, true
+}
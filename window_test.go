@@ -0,0 +1,89 @@
+package cronrange
+
+import "testing"
+
+func TestParseTimeWindow(t *testing.T) {
+	tests := []struct {
+		name      string
+		window    string
+		wantStart string
+		wantEnd   string
+		wantErr   bool
+	}{
+		{"Empty", "", "", "", true},
+		{"Missing ending time", "09:00", "", "", true},
+		{"Missing colon", "0900-1700", "", "", true},
+		{"Invalid hour", "25:00-17:00", "", "", true},
+		{"Invalid minute", "09:60-17:00", "", "", true},
+		{"Invalid short minute", "09:0-17:00", "", "", true},
+		{"Midnight as starting time", "24:00-06:00", "", "", true},
+		{"Invalid day name", "Mon-Fry 09:00-17:00", "", "", true},
+		{"Invalid day number", "1-8 09:00-17:00", "", "", true},
+		{"Too many fields", "Mon Fri 09:00-17:00", "", "", true},
+		{"Daily", "09:00-17:00", "0 9 * * *", "0 17 * * *", false},
+		{"Short hour", "9:30-17:45", "30 9 * * *", "45 17 * * *", false},
+		{"Overnight", "22:00-06:00", "0 22 * * *", "0 6 * * *", false},
+		{"Until midnight", "18:00-24:00", "0 18 * * *", "0 0 * * *", false},
+		{"Weekdays", "Mon-Fri 09:00-17:00", "0 9 * * 1-5", "0 17 * * *", false},
+		{"Weekend in lower case", "sat,sun 10:00-16:00", "0 10 * * 6,0", "0 16 * * *", false},
+		{"Range to Sunday", "Fri-Sun 22:00-02:00", "0 22 * * 5-6,0", "0 2 * * *", false},
+		{"Range from Saturday to Sunday", "Sat-Sun 10:00-12:00", "0 10 * * 6,0", "0 12 * * *", false},
+		{"Range to Sunday as 7", "3-7 10:00-12:00", "0 10 * * 3-6,0", "0 12 * * *", false},
+		{"Sunday as 7", "7 10:00-12:00", "0 10 * * 0", "0 12 * * *", false},
+		{"Range wrapping around the week", "Fri-Mon 22:00-06:00", "0 22 * * 5-6,0-1", "0 6 * * *", false},
+		{"Range wrapping from Saturday", "Sat-Tue 22:00-06:00", "0 22 * * 6,0-2", "0 6 * * *", false},
+		{"Numbers and names", "1,3,FRI 08:15-08:45", "15 8 * * 1,3,5", "45 8 * * *", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotStart, gotEnd, err := parseTimeWindow(tt.window)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseTimeWindow() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotStart != tt.wantStart || gotEnd != tt.wantEnd {
+				t.Errorf("parseTimeWindow() got = %q, %q, want %q, %q", gotStart, gotEnd, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func BenchmarkParseTimeWindow(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _, _ = parseTimeWindow("Mon-Fri 09:00-17:00")
+	}
+}
+
+func TestFormatTimeWindow(t *testing.T) {
+	tests := []struct {
+		name      string
+		startExpr string
+		endExpr   string
+		want      string
+		wantOK    bool
+	}{
+		{"Daily", "0 9 * * *", "0 17 * * *", "09:00-17:00", true},
+		{"Overnight", "30 22 * * *", "0 6 * * *", "22:30-06:00", true},
+		{"Weekdays", "0 9 * * 1-5", "0 17 * * *", "Mon-Fri 09:00-17:00", true},
+		{"Day names", "0 9 * * mon,WED,7", "0 17 * * *", "Mon,Wed,Sun 09:00-17:00", true},
+		{"Range to Sunday", "0 22 * * 5-7", "0 2 * * *", "Fri-Sun 22:00-02:00", true},
+		{"Split range to Sunday", "0 22 * * 5-6,0", "0 2 * * *", "Fri-Sun 22:00-02:00", true},
+		{"Split range wrapping around the week", "0 22 * * 5-6,0-1", "0 6 * * *", "Fri-Mon 22:00-06:00", true},
+		{"Multiple hours", "0 9,13 * * *", "0 17 * * *", "", false},
+		{"Step of minutes", "*/5 9 * * *", "0 17 * * *", "", false},
+		{"Day of month", "0 9 1 * *", "0 17 * * *", "", false},
+		{"Day of week in end expression", "0 18 * * 5", "0 6 * * 1", "", false},
+		{"Step of days", "0 9 * * 1-5/2", "0 17 * * *", "", false},
+		{"Quartz operator", "0 9 * * 5L", "0 17 * * *", "", false},
+		{"Descriptor", "@daily", "0 17 * * *", "", false},
+		{"No end expression", "0 9 * * *", "", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := formatTimeWindow(tt.startExpr, tt.endExpr)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("formatTimeWindow() got = %q, %v, want %q, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}